@@ -1,69 +1,45 @@
 package vectors
 
 import (
+	"fmt"
 	"reflect"
 )
 
-type Float interface {
-	~float64 | []float64 | int64 | []int64 | int | []int | int32 | []int32
+// Number is the set of integer and floating point element types accepted by the generic functions.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
 }
 
-// IsVector checks if a variable is a slice
-func IsVector[T Float](obj T) bool {
-	switch reflect.TypeOf(obj).Kind() {
-	case reflect.Slice:
-		return true
-	default:
-		return false
-	}
-}
-
-// AsSlice converts a variable to a slice
-func AsSlice[T Float](obj T) []float64 {
-	switch reflect.TypeOf(obj).Kind() {
-	case reflect.Slice:
-		return any(obj).([]float64)
-	default:
-		return []float64{any(obj).(float64)}
+// Cast converts the elements of a slice to another number type, truncating towards zero like a Go
+// conversion when the target is an integer type
+func Cast[To, From Number](slice []From) []To {
+	var result []To
+	for _, v := range slice {
+		result = append(result, To(v))
 	}
+	return result
 }
 
-// asFloat64 converts a variable to a float64
-func asFloat64[T Float](obj T) float64 {
-	switch reflect.TypeOf(obj).Kind() {
-	case reflect.Slice:
-		return any(obj).([]float64)[0]
-	case reflect.Int:
-		return float64(any(obj).(int))
-	case reflect.Int64:
-		return float64(any(obj).(int))
-	case reflect.Int32:
-		return float64(any(obj).(int))
-	default:
-		return any(obj).(float64)
+// toFloat64s returns a slice of numbers as float64 values, without copying a []float64
+func toFloat64s[T Number](array []T) []float64 {
+	if floats, ok := any(array).([]float64); ok {
+		return floats
 	}
+	return ConvertFloat(array)
 }
 
-// ConvertFloat converts a int type slice to a float64 slice
-func ConvertFloat(slice []int) []float64 {
-	var result []float64
-	for _, v := range slice {
-		result = append(result, float64(v))
-	}
-	return result
+// ConvertFloat converts a slice of numbers to a float64 slice
+func ConvertFloat[T Number](slice []T) []float64 {
+	return Cast[float64](slice)
 }
 
-// ConvertInt converts a float64 type slice to a int slice
-func ConvertInt(slice []float64) []int {
-	var result []int
-	for _, v := range slice {
-		result = append(result, int(v))
-	}
-	return result
+// ConvertInt converts a slice of numbers to a int slice
+func ConvertInt[T Number](slice []T) []int {
+	return Cast[int](slice)
 }
 
 // Contains checks if a value is in a slice
-func Contains[T float64 | string | int](slice []T, val T) bool {
+func Contains[T comparable](slice []T, val T) bool {
 	for _, elem := range slice {
 		if elem == val {
 			return true
@@ -73,8 +49,8 @@ func Contains[T float64 | string | int](slice []T, val T) bool {
 }
 
 // Unique returns a slice of unique values
-func Unique(data []float64) []float64 {
-	var uniqueData []float64
+func Unique[T Number](data []T) []T {
+	var uniqueData []T
 
 	for _, value := range data {
 		if !Contains(uniqueData, value) {
@@ -88,8 +64,8 @@ func Unique(data []float64) []float64 {
 // MultiplyBy multiplies a slice of numbers by a number
 
 // Apply applies a function to a slice of numbers
-func Apply(array []float64, f func(float642 float64) float64) []float64 {
-	var appliedArray []float64
+func Apply[T Number](array []T, f func(T) T) []T {
+	var appliedArray []T
 	for i := range array {
 		appliedArray = append(appliedArray, f(array[i]))
 	}
@@ -98,8 +74,8 @@ func Apply(array []float64, f func(float642 float64) float64) []float64 {
 }
 
 // Arange returns a slice of numbers from start to end with a step
-func Arange(start, stop, step float64) []float64 {
-	var array []float64
+func Arange[T Number](start, stop, step T) []T {
+	var array []T
 
 	for i := start; i < stop; i += step {
 		array = append(array, i)
@@ -109,9 +85,9 @@ func Arange(start, stop, step float64) []float64 {
 }
 
 // Where returns the indices and elements of a slice of numbers that satisfy a condition
-func Where(array []float64, f func(float642 float64) bool) ([]int, []float64) {
+func Where[T Number](array []T, f func(T) bool) ([]int, []T) {
 	var indices []int
-	var elements []float64
+	var elements []T
 
 	for i, value := range array {
 		if f(value) {
@@ -146,10 +122,19 @@ func Ones(length int) []float64 {
 }
 
 // Insert inserts an element into a slice at a given index
-func Insert(array []float64, index int, value float64) []float64 {
-	var newArray []float64
+func Insert[T Number](array []T, index int, value T) []T {
+	newArray, err := TryInsert(array, index, value)
+	if err != nil {
+		panic(err)
+	}
+	return newArray
+}
+
+// TryInsert is Insert returning ErrIndexOutOfRange instead of panicking
+func TryInsert[T Number](array []T, index int, value T) ([]T, error) {
+	var newArray []T
 	if index < 0 || index > len(array)-1 {
-		panic("index is out of range")
+		return nil, fmt.Errorf("%w: insert at index %d of a slice of length %d", ErrIndexOutOfRange, index, len(array))
 	}
 
 	for i := 0; i < index; i++ {
@@ -159,14 +144,23 @@ func Insert(array []float64, index int, value float64) []float64 {
 	for i := index; i < len(array); i++ {
 		newArray = append(newArray, array[i])
 	}
-	return newArray
+	return newArray, nil
 }
 
 // Delete deletes an element from a slice at a given index
-func Delete(array []float64, index int) []float64 {
-	var newArray []float64
+func Delete[T Number](array []T, index int) []T {
+	newArray, err := TryDelete(array, index)
+	if err != nil {
+		panic(err)
+	}
+	return newArray
+}
+
+// TryDelete is Delete returning ErrIndexOutOfRange instead of panicking
+func TryDelete[T Number](array []T, index int) ([]T, error) {
+	var newArray []T
 	if index < 0 || index > len(array)-1 {
-		panic("index is out of range")
+		return nil, fmt.Errorf("%w: delete at index %d of a slice of length %d", ErrIndexOutOfRange, index, len(array))
 	}
 
 	for i := 0; i < index; i++ {
@@ -175,11 +169,11 @@ func Delete(array []float64, index int) []float64 {
 	for i := index + 1; i < len(array); i++ {
 		newArray = append(newArray, array[i])
 	}
-	return newArray
+	return newArray, nil
 }
 
 // Any returns true if any element of a slice satisfies a condition
-func Any(array []float64, f func(float642 float64) bool) bool {
+func Any[T Number](array []T, f func(T) bool) bool {
 	for i := range array {
 		if f(array[i]) {
 			return true
@@ -189,7 +183,7 @@ func Any(array []float64, f func(float642 float64) bool) bool {
 }
 
 // All returns true if all elements of a slice satisfy a condition
-func All(array []float64, f func(float642 float64) bool) bool {
+func All[T Number](array []T, f func(T) bool) bool {
 	for i := range array {
 		if !f(array[i]) {
 			return false
@@ -199,7 +193,7 @@ func All(array []float64, f func(float642 float64) bool) bool {
 }
 
 // Size returns the total number of elements in a slice
-func Size(array [][]float64) int {
+func Size[T Number](array [][]T) int {
 	size := 0
 	for _, elem := range array {
 		size += len(elem)
@@ -209,11 +203,11 @@ func Size(array [][]float64) int {
 }
 
 // ColumnStack takes a sequence of 1-D arrays and stack them as columns to make a single 2-D array
-func ColumnStack(arrays ...[]float64) [][]float64 {
-	var result [][]float64
+func ColumnStack[T Number](arrays ...[]T) [][]T {
+	var result [][]T
 
 	for i := range arrays[0] {
-		var row []float64
+		var row []T
 		for _, array := range arrays {
 			row = append(row, array[i])
 		}
@@ -224,10 +218,10 @@ func ColumnStack(arrays ...[]float64) [][]float64 {
 }
 
 // Transpose returns the transpose of a 2-D array
-func Transpose(array [][]float64) [][]float64 {
-	var result [][]float64
+func Transpose[T Number](array [][]T) [][]T {
+	var result [][]T
 	for i := range array[0] {
-		var row []float64
+		var row []T
 		for _, elem := range array {
 			row = append(row, elem[i])
 		}
@@ -250,7 +244,7 @@ func IsComplex[T ~float64 | complex128](array []T) []bool {
 }
 
 // SearchSorted returns the indices of the sorted array that contain elements in the input array
-func SearchSorted(array []float64, vals []float64, side string) []int {
+func SearchSorted[T Number](array []T, vals []T, side string) []int {
 	var indices []int
 	for _, val := range vals {
 		var indice int
@@ -259,7 +253,7 @@ func SearchSorted(array []float64, vals []float64, side string) []int {
 		} else if val > array[len(array)-1] {
 			indice = len(array) - 1
 		} else {
-			filterFunc := func(x float64) bool {
+			filterFunc := func(x T) bool {
 				return x >= val
 			}
 			indexes, _ := Where(array, filterFunc)
@@ -275,8 +269,8 @@ func SearchSorted(array []float64, vals []float64, side string) []int {
 }
 
 // RowStack takes a sequence of 1-D arrays and stack them as rows to make a single 2-D array
-func RowStack(arrays ...[]float64) [][]float64 {
-	var result [][]float64
+func RowStack[T Number](arrays ...[]T) [][]T {
+	var result [][]T
 	for _, array := range arrays {
 		result = append(result, array)
 	}
@@ -284,8 +278,8 @@ func RowStack(arrays ...[]float64) [][]float64 {
 }
 
 // Roll returns an array with elements that roll beyond the last position are re-introduced at the first.
-func Roll(array []float64, shift int) []float64 {
-	var result []float64
+func Roll[T Number](array []T, shift int) []T {
+	var result []T
 	for i := len(array) - shift; i < len(array); i++ {
 		result = append(result, array[i])
 	}
@@ -297,7 +291,7 @@ func Roll(array []float64, shift int) []float64 {
 }
 
 // CheckConsistency returns true if length of all the rows are the same
-func CheckConsistency(array [][]float64) bool {
+func CheckConsistency[T Number](array [][]T) bool {
 	for i := 1; i < len(array); i++ {
 		if len(array[i]) != len(array[i-1]) {
 			return false
@@ -307,8 +301,8 @@ func CheckConsistency(array [][]float64) bool {
 }
 
 // Flipud returns an array with the elements reversed.
-func Flipud(array []float64) []float64 {
-	var result []float64
+func Flipud[T Number](array []T) []T {
+	var result []T
 	for i := len(array) - 1; i >= 0; i-- {
 		result = append(result, array[i])
 	}
@@ -316,8 +310,8 @@ func Flipud(array []float64) []float64 {
 }
 
 // Tile returns an array with the elements repeated the number of times given by the input array.
-func Tile(array []float64, reps int) [][]float64 {
-	var result [][]float64
+func Tile[T Number](array []T, reps int) [][]T {
+	var result [][]T
 	for i := 0; i < reps; i++ {
 		result = append(result, array)
 	}
@@ -325,8 +319,8 @@ func Tile(array []float64, reps int) [][]float64 {
 }
 
 // Meshgrid returns two arrays with the coordinates of the points in a meshgrid.
-func Meshgrid(x, y []float64) ([][]float64, [][]float64) {
-	var xGrid, yGrid [][]float64
+func Meshgrid[T Number](x, y []T) ([][]T, [][]T) {
+	var xGrid, yGrid [][]T
 	for i := 0; i < len(y); i++ {
 		xGrid = append(xGrid, x)
 	}
@@ -337,17 +331,29 @@ func Meshgrid(x, y []float64) ([][]float64, [][]float64) {
 }
 
 //GetColumn returns a column of a 2-D array
-func GetColumn(array [][]float64, index int) []float64 {
-	var result []float64
-	for _, row := range array {
-		result = append(result, row[index])
+func GetColumn[T Number](array [][]T, index int) []T {
+	result, err := TryGetColumn(array, index)
+	if err != nil {
+		panic(err)
 	}
 	return result
 }
 
+// TryGetColumn is GetColumn returning ErrIndexOutOfRange instead of panicking when a row is too short
+func TryGetColumn[T Number](array [][]T, index int) ([]T, error) {
+	var result []T
+	for i, row := range array {
+		if index < 0 || index >= len(row) {
+			return nil, fmt.Errorf("%w: column %d of row %d with length %d", ErrIndexOutOfRange, index, i, len(row))
+		}
+		result = append(result, row[index])
+	}
+	return result, nil
+}
+
 // Repat returns an array with the elements repeated the number of times given by the input value.
-func Repeat(elem float64, reps int) []float64 {
-	var result []float64
+func Repeat[T Number](elem T, reps int) []T {
+	var result []T
 	for i := 0; i < reps; i++ {
 		result = append(result, elem)
 	}
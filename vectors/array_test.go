@@ -1,6 +1,7 @@
 package vectors
 
 import (
+	"errors"
 	"math"
 	"reflect"
 	"testing"
@@ -49,6 +50,10 @@ func TestArange(t *testing.T) {
 	if reflect.DeepEqual(expected, output) != true {
 		t.Errorf("Arange(%d,%d,%f) = %v, want %v", 0, 1, 0.1, output, expected)
 	}
+	// integer arguments give integer elements like numpy.arange
+	if output := Arange(1, 10, 3); reflect.DeepEqual([]int{1, 4, 7}, output) != true {
+		t.Errorf("Got %v, want [1 4 7]", output)
+	}
 }
 
 func TestWhere(t *testing.T) {
@@ -88,6 +93,22 @@ func TestInsert(t *testing.T) {
 	}
 }
 
+func TestTryInsert(t *testing.T) {
+	if _, err := TryInsert([]float64{1, 2}, 5, 3); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Got %v, want %v", err, ErrIndexOutOfRange)
+	}
+	if _, err := TryDelete([]float64{}, 0); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Got %v, want %v", err, ErrIndexOutOfRange)
+	}
+	if _, err := TryGetColumn([][]float64{{1, 2}, {3}}, 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Got %v, want %v", err, ErrIndexOutOfRange)
+	}
+	output, err := TryInsert([]float64{1, 3}, 1, 2)
+	if err != nil || reflect.DeepEqual([]float64{1, 2, 3}, output) != true {
+		t.Errorf("Got %v, %v, want [1 2 3]", output, err)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	expected := []float64{1, 3, 4, 5, 3}
 	output := Delete(ConvertFloat(testSliceInt), 1)
@@ -280,10 +301,28 @@ func TestConvertInt(t *testing.T) {
 	}
 }
 
+func TestCast(t *testing.T) {
+	expected := []int8{1, -2, 3}
+	output := Cast[int8]([]float32{1.7, -2.2, 3})
+	if reflect.DeepEqual(expected, output) != true {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	expectedFloat := []float64{1, 2, 255}
+	outputFloat := ConvertFloat([]uint8{1, 2, 255})
+	if reflect.DeepEqual(expectedFloat, outputFloat) != true {
+		t.Errorf("Got %v, want %v", outputFloat, expectedFloat)
+	}
+}
+
 func TestRepeat(t *testing.T) {
 	expected := []float64{2, 2, 2}
-	output := Repeat(2, 3)
+	output := Repeat(2.0, 3)
 	if reflect.DeepEqual(expected, output) != true {
 		t.Errorf("Got %v, want %v", output, expected)
 	}
+	// the element type follows the repeated value
+	if output := Repeat(uint8(7), 2); reflect.DeepEqual([]uint8{7, 7}, output) != true {
+		t.Errorf("Got %v, want [7 7]", output)
+	}
 }
@@ -0,0 +1,158 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ShapeError reports operands whose shapes cannot be broadcast together.
+type ShapeError struct {
+	Shapes [][]int
+}
+
+func (err *ShapeError) Error() string {
+	shapes := make([]string, len(err.Shapes))
+	for i, shape := range err.Shapes {
+		shapes[i] = fmt.Sprint(shape)
+	}
+	return "operands could not be broadcast together with shapes " + strings.Join(shapes, " ")
+}
+
+// Is reports ShapeError as an ErrShapeMismatch.
+func (err *ShapeError) Is(target error) bool {
+	return target == ErrShapeMismatch
+}
+
+// BroadcastShapes returns the shape the given shapes broadcast to under the NumPy rules: the shapes are
+// aligned at their last dimension and each dimension must either match or be 1.
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+	ndim := 0
+	for _, shape := range shapes {
+		if len(shape) > ndim {
+			ndim = len(shape)
+		}
+	}
+	result := make([]int, ndim)
+	for i := range result {
+		result[i] = 1
+	}
+	for _, shape := range shapes {
+		for i, dim := range shape {
+			axis := ndim - len(shape) + i
+			switch {
+			case dim == result[axis] || dim == 1:
+			case result[axis] == 1:
+				result[axis] = dim
+			default:
+				return nil, &ShapeError{Shapes: shapes}
+			}
+		}
+	}
+	return result, nil
+}
+
+// NDScalar returns a 0-dimensional array holding value, which broadcasts against any shape.
+func NDScalar(value float64) *NDArray {
+	return NewNDArray([]float64{value})
+}
+
+// BroadcastTo returns a read-only view of the array repeated along the dimensions it is broadcast over,
+// using zero strides instead of copying. Set panics on the view and on views taken from it; Copy gives
+// a writable array.
+func (array *NDArray) BroadcastTo(shape ...int) (*NDArray, error) {
+	ndim := len(shape)
+	if ndim < len(array.shape) {
+		return nil, &ShapeError{Shapes: [][]int{array.Shape(), shape}}
+	}
+	view := &NDArray{data: array.data, shape: append([]int{}, shape...), strides: make([]int, ndim), offset: array.offset, readOnly: true}
+	for i, dim := range array.shape {
+		axis := ndim - len(array.shape) + i
+		switch {
+		case dim == shape[axis]:
+			view.strides[axis] = array.strides[i]
+		case dim != 1:
+			return nil, &ShapeError{Shapes: [][]int{array.Shape(), shape}}
+		}
+	}
+	return view, nil
+}
+
+// Broadcast applies op element-wise to x and y after broadcasting them to a common shape, returning
+// a new array.
+func Broadcast(x, y *NDArray, op func(a, b float64) float64) (*NDArray, error) {
+	shape, err := BroadcastShapes(x.shape, y.shape)
+	if err != nil {
+		return nil, err
+	}
+	bx, _ := x.BroadcastTo(shape...)
+	by, _ := y.BroadcastTo(shape...)
+	result := bx.Flatten()
+	for i, v := range by.Flatten() {
+		result[i] = op(result[i], v)
+	}
+	return NewNDArray(result, shape...), nil
+}
+
+// Add returns the element-wise sum of the array and other after broadcasting.
+func (array *NDArray) Add(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, func(a, b float64) float64 { return a + b })
+}
+
+// Sub returns the element-wise difference of the array and other after broadcasting.
+func (array *NDArray) Sub(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, func(a, b float64) float64 { return a - b })
+}
+
+// Mul returns the element-wise product of the array and other after broadcasting.
+func (array *NDArray) Mul(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, func(a, b float64) float64 { return a * b })
+}
+
+// Div returns the element-wise quotient of the array and other after broadcasting.
+func (array *NDArray) Div(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, func(a, b float64) float64 { return a / b })
+}
+
+// Pow raises the elements of the array to the powers in other after broadcasting.
+func (array *NDArray) Pow(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, math.Pow)
+}
+
+// Mod returns the element-wise remainder of the division by other after broadcasting, with the sign
+// of the dividend like Mod.
+func (array *NDArray) Mod(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, math.Mod)
+}
+
+// FloorDiv returns the floor of the element-wise quotient of the array and other after broadcasting.
+func (array *NDArray) FloorDiv(other *NDArray) (*NDArray, error) {
+	return Broadcast(array, other, func(a, b float64) float64 { return math.Floor(a / b) })
+}
+
+// broadcastSlices applies op to the elements of two 1-D slices broadcast against each other, so a slice
+// of length one acts as a scalar. It panics with a *ShapeError when the lengths are incompatible.
+func broadcastSlices(x, y []float64, op func(a, b float64) float64) []float64 {
+	result, err := tryBroadcastSlices(x, y, op)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// tryBroadcastSlices is broadcastSlices returning the *ShapeError instead of panicking
+func tryBroadcastSlices(x, y []float64, op func(a, b float64) float64) ([]float64, error) {
+	n := len(x)
+	switch {
+	case len(x) == len(y):
+	case len(x) == 1:
+		n = len(y)
+	case len(y) != 1:
+		return nil, &ShapeError{Shapes: [][]int{{len(x)}, {len(y)}}}
+	}
+	var result []float64
+	for i := 0; i < n; i++ {
+		result = append(result, op(x[i%len(x)], y[i%len(y)]))
+	}
+	return result, nil
+}
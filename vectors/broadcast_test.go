@@ -0,0 +1,102 @@
+package vectors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBroadcastShapes(t *testing.T) {
+	for _, c := range []struct {
+		shapes   [][]int
+		expected []int
+	}{
+		{[][]int{{3, 1}, {4}}, []int{3, 4}},
+		{[][]int{{8, 1, 6, 1}, {7, 1, 5}}, []int{8, 7, 6, 5}},
+		{[][]int{{5, 4}, {}}, []int{5, 4}},
+		{[][]int{{0}, {1}}, []int{0}},
+	} {
+		output, err := BroadcastShapes(c.shapes...)
+		if err != nil || !reflect.DeepEqual(c.expected, output) {
+			t.Errorf("BroadcastShapes(%v) = %v, %v, want %v", c.shapes, output, err, c.expected)
+		}
+	}
+
+	_, err := BroadcastShapes([]int{2, 1}, []int{8, 4, 3})
+	var shapeErr *ShapeError
+	if !errors.As(err, &shapeErr) || err.Error() != "operands could not be broadcast together with shapes [2 1] [8 4 3]" {
+		t.Errorf("Got %v, want a ShapeError", err)
+	}
+}
+
+func TestBroadcastTo(t *testing.T) {
+	row := NewNDArray([]float64{1, 2, 3}, 3)
+	view, err := row.BroadcastTo(2, 3)
+	expected := [][]float64{{1, 2, 3}, {1, 2, 3}}
+	if err != nil || !reflect.DeepEqual(expected, view.To2D()) || !reflect.DeepEqual([]int{0, 1}, view.Strides()) {
+		t.Errorf("Got %v, %v, want %v", view, err, expected)
+	}
+	if _, err := row.BroadcastTo(3, 2); err == nil {
+		t.Errorf("BroadcastTo an incompatible shape returned no error")
+	}
+
+	// writing through the view, or a view of it, panics and leaves the source untouched
+	for _, v := range []*NDArray{view, view.T(), view.Index(0, 1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Set on the broadcast view %v did not panic", v)
+				}
+			}()
+			v.Set(9, make([]int, v.Ndim())...)
+		}()
+	}
+	if !reflect.DeepEqual([]float64{1, 2, 3}, row.Flatten()) {
+		t.Errorf("Got %v, want the source unchanged", row)
+	}
+	writable := view.Copy()
+	writable.Set(9, 0, 0)
+	if writable.At(0, 0) != 9 || writable.At(1, 0) != 1 {
+		t.Errorf("Got %v, want a writable copy", writable)
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	// a column vector times a row vector gives their outer product
+	column := NewNDArray([]float64{1, 2, 3}, 3, 1)
+	row := NewNDArray([]float64{10, 20}, 2)
+	output, err := column.Mul(row)
+	expected := [][]float64{{10, 20}, {20, 40}, {30, 60}}
+	if err != nil || !reflect.DeepEqual(expected, output.To2D()) {
+		t.Errorf("Got %v, %v, want %v", output, err, expected)
+	}
+
+	matrix := NewNDArray(Arange(0.0, 6, 1), 2, 3)
+	for name, c := range map[string]struct {
+		f        func(*NDArray) (*NDArray, error)
+		expected []float64
+	}{
+		"Add":      {matrix.Add, []float64{10, 11, 12, 13, 14, 15}},
+		"Sub":      {matrix.Sub, []float64{-10, -9, -8, -7, -6, -5}},
+		"Div":      {matrix.Div, []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5}},
+		"Pow":      {matrix.Pow, []float64{0, 1, 1024, 59049, 1048576, 9765625}},
+		"Mod":      {matrix.Mod, []float64{0, 1, 2, 3, 4, 5}},
+		"FloorDiv": {matrix.FloorDiv, []float64{0, 0, 0, 0, 0, 0}},
+	} {
+		output, err := c.f(NDScalar(10))
+		if err != nil || !AllClose(c.expected, output.Flatten(), 1e-12) {
+			t.Errorf("%s = %v, %v, want %v", name, output, err, c.expected)
+		}
+	}
+
+	// broadcasting works on transposed views
+	output, _ = matrix.T().Add(NewNDArray([]float64{100, 200}, 2))
+	expected = [][]float64{{100, 203}, {101, 204}, {102, 205}}
+	if !reflect.DeepEqual(expected, output.To2D()) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	if _, err := matrix.Add(row); err == nil {
+		t.Errorf("Add of shapes [2 3] and [2] returned no error")
+	}
+}
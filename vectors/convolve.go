@@ -0,0 +1,224 @@
+package vectors
+
+import (
+	"fmt"
+)
+
+// Convolve returns the discrete linear convolution of a and v like numpy.convolve. mode is "full"
+// (default) for the convolution at each point of overlap, "same" for max(len(a), len(v)) points centred
+// on the full output or "valid" for the points where the inputs overlap completely. Like numpy it always
+// uses the direct sum, which keeps integer valued inputs exact; FFTConvolve is faster for long inputs.
+func Convolve(a, v []float64, mode string) []float64 {
+	if len(a) == 0 || len(v) == 0 {
+		panic("convolve: inputs cannot be empty")
+	}
+	return cropConvolution("convolve", directConvolve(a, v), len(a), len(v), mode, false)
+}
+
+// Correlate returns the cross-correlation of a and v like numpy.correlate, c[k] = sum_n a[n+k] v[n],
+// which is the convolution of a with v reversed. mode is as in Convolve but defaults to "valid". When v
+// is longer than a, numpy correlates v with a and reverses the result, which shifts the points kept in
+// "same" mode when len(a) is even. CorrelationLags gives the lag of each point.
+func Correlate(a, v []float64, mode string) []float64 {
+	if mode == "" {
+		mode = "valid"
+	}
+	if len(v) > len(a) {
+		return Flipud(Convolve(v, Flipud(a), mode))
+	}
+	return Convolve(a, Flipud(v), mode)
+}
+
+// directConvolve returns the full convolution of a and v by the direct sum
+func directConvolve(a, v []float64) []float64 {
+	result := make([]float64, len(a)+len(v)-1)
+	for i, x := range a {
+		for j, y := range v {
+			result[i+j] += x * y
+		}
+	}
+	return result
+}
+
+// fftConvolveFull returns the full convolution of a and v as the inverse FFT of the product of their
+// zero-padded spectra
+func fftConvolveFull(a, v []float64) []float64 {
+	n := len(a) + len(v) - 1
+	nfft := 1
+	for nfft < n {
+		nfft *= 2
+	}
+	fa := RFFT(a, nfft, "")
+	fv := RFFT(v, nfft, "")
+	for i := range fa {
+		fa[i] *= fv[i]
+	}
+	return IRFFT(fa, nfft, "")[:n]
+}
+
+// cropConvolution returns the part of the full convolution of inputs of n and m points selected by mode.
+// "same" has max(n, m) points like numpy, or n points like scipy when sameAsFirst is set.
+func cropConvolution(name string, full []float64, n, m int, mode string, sameAsFirst bool) []float64 {
+	large, small := n, m
+	if m > n {
+		large, small = m, n
+	}
+	switch mode {
+	case "", "full":
+		return full
+	case "same":
+		if sameAsFirst {
+			start := (len(full) - n) / 2
+			return full[start : start+n]
+		}
+		start := (small - 1) / 2
+		return full[start : start+large]
+	case "valid":
+		return full[small-1 : large]
+	default:
+		panic(fmt.Sprintf("%s: unknown mode %q, should be \"full\", \"same\" or \"valid\"", name, mode))
+	}
+}
+
+// FFTConvolve returns the convolution of in1 and in2 computed with the FFT like scipy.signal.fftconvolve,
+// which is faster than the direct sum for long inputs. mode is as in Convolve except that "same" returns
+// len(in1) points centred on the full output.
+func FFTConvolve(in1, in2 []float64, mode string) []float64 {
+	if len(in1) == 0 || len(in2) == 0 {
+		panic("fftconvolve: inputs cannot be empty")
+	}
+	return cropConvolution("fftconvolve", fftConvolveFull(in1, in2), len(in1), len(in2), mode, true)
+}
+
+// OAConvolve returns the convolution of in1 and in2 computed with the overlap-add method like
+// scipy.signal.oaconvolve: the longer input is cut into blocks, each convolved with the shorter one by an
+// FFT of a few times its length, which is faster than FFTConvolve when the lengths differ greatly. mode is
+// as in FFTConvolve.
+func OAConvolve(in1, in2 []float64, mode string) []float64 {
+	if len(in1) == 0 || len(in2) == 0 {
+		panic("oaconvolve: inputs cannot be empty")
+	}
+	signal, kernel := in1, in2
+	if len(kernel) > len(signal) {
+		signal, kernel = kernel, signal
+	}
+	nfft := 1
+	for nfft < 2*len(kernel) {
+		nfft *= 2
+	}
+	nfft *= 4
+	block := nfft - len(kernel) + 1
+	if block >= len(signal) {
+		return FFTConvolve(in1, in2, mode)
+	}
+
+	spectrum := RFFT(kernel, nfft, "")
+	full := make([]float64, len(signal)+len(kernel)-1)
+	for start := 0; start < len(signal); start += block {
+		stop := start + block
+		if stop > len(signal) {
+			stop = len(signal)
+		}
+		part := RFFT(signal[start:stop], nfft, "")
+		for i := range part {
+			part[i] *= spectrum[i]
+		}
+		for i, v := range IRFFT(part, nfft, "")[:stop-start+len(kernel)-1] {
+			full[start+i] += v
+		}
+	}
+	return cropConvolution("oaconvolve", full, len(in1), len(in2), mode, true)
+}
+
+// Convolve2D returns the 2-D convolution of the matrices in1 and in2 like scipy.signal.convolve2d with
+// zero fill at the boundaries. mode is "full" (default), "same" for the size of in1 centred on the full
+// output or "valid" for the points where the inputs overlap completely, which requires one input to be at
+// least as large as the other in both dimensions.
+func Convolve2D(in1, in2 [][]float64, mode string) [][]float64 {
+	if len(in1) == 0 || len(in2) == 0 || len(in1[0]) == 0 || len(in2[0]) == 0 {
+		panic("convolve2d: inputs cannot be empty")
+	}
+	if !CheckConsistency(in1) || !CheckConsistency(in2) {
+		panic("convolve2d: rows must have the same length")
+	}
+	n1, m1, n2, m2 := len(in1), len(in1[0]), len(in2), len(in2[0])
+	full := Zeros(n1+n2-1, m1+m2-1)
+	for i, row := range in1 {
+		for j, x := range row {
+			for p, kernelRow := range in2 {
+				for q, y := range kernelRow {
+					full[i+p][j+q] += x * y
+				}
+			}
+		}
+	}
+
+	var rowStart, rows, colStart, cols int
+	switch mode {
+	case "", "full":
+		return full
+	case "same":
+		rowStart, rows = (n2-1)/2, n1
+		colStart, cols = (m2-1)/2, m1
+	case "valid":
+		switch {
+		case n1 >= n2 && m1 >= m2:
+			rowStart, rows, colStart, cols = n2-1, n1-n2+1, m2-1, m1-m2+1
+		case n2 >= n1 && m2 >= m1:
+			rowStart, rows, colStart, cols = n1-1, n2-n1+1, m1-1, m2-m1+1
+		default:
+			panic("convolve2d: for 'valid' mode, one must be at least as large as the other in every dimension")
+		}
+	default:
+		panic(fmt.Sprintf("convolve2d: unknown mode %q, should be \"full\", \"same\" or \"valid\"", mode))
+	}
+	result := make([][]float64, rows)
+	for i := range result {
+		result[i] = full[rowStart+i][colStart : colStart+cols : colStart+cols]
+	}
+	return result
+}
+
+// Correlate2D returns the 2-D cross-correlation of the matrices in1 and in2 like scipy.signal.correlate2d,
+// the convolution of in1 with in2 rotated by 180 degrees. mode is as in Convolve2D.
+func Correlate2D(in1, in2 [][]float64, mode string) [][]float64 {
+	rotated := make([][]float64, len(in2))
+	for i, row := range in2 {
+		rotated[len(in2)-1-i] = Flipud(row)
+	}
+	return Convolve2D(in1, rotated, mode)
+}
+
+// CorrelationLags returns the lag of each point of the cross-correlation of inputs of in1Len and in2Len
+// points like scipy.signal.correlation_lags, so that a peak of Correlate(in1, in2, mode) at index i means
+// in1 is delayed by lags[i] samples relative to in2. For "same" mode it assumes in1Len >= in2Len.
+func CorrelationLags(in1Len, in2Len int, mode string) []int {
+	var lags []int
+	switch mode {
+	case "", "full":
+		for lag := -(in2Len - 1); lag < in1Len; lag++ {
+			lags = append(lags, lag)
+		}
+	case "same":
+		full := CorrelationLags(in1Len, in2Len, "full")
+		mid, bound := len(full)/2, in1Len/2
+		if in1Len%2 == 0 {
+			return full[mid-bound : mid+bound]
+		}
+		return full[mid-bound : mid+bound+1]
+	case "valid":
+		bound := in1Len - in2Len
+		if bound >= 0 {
+			for lag := 0; lag <= bound; lag++ {
+				lags = append(lags, lag)
+			}
+		} else {
+			for lag := bound; lag <= 0; lag++ {
+				lags = append(lags, lag)
+			}
+		}
+	default:
+		panic(fmt.Sprintf("correlation_lags: unknown mode %q, should be \"full\", \"same\" or \"valid\"", mode))
+	}
+	return lags
+}
@@ -0,0 +1,129 @@
+package vectors
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConvolve(t *testing.T) {
+	a, v := []float64{1, 2, 3}, []float64{0, 1, 0.5}
+	if output := Convolve(a, v, ""); !reflect.DeepEqual([]float64{0, 1, 2.5, 4, 1.5}, output) {
+		t.Errorf("Got %v, want [0 1 2.5 4 1.5]", output)
+	}
+	if output := Convolve(a, v, "same"); !reflect.DeepEqual([]float64{1, 2.5, 4}, output) {
+		t.Errorf("Got %v, want [1 2.5 4]", output)
+	}
+	if output := Convolve(a, v, "valid"); !reflect.DeepEqual([]float64{2.5}, output) {
+		t.Errorf("Got %v, want [2.5]", output)
+	}
+	// the shorter input is centred in "same" mode whichever argument it is
+	if output := Convolve([]float64{1, 2}, []float64{1, 1, 1, 1}, "same"); !reflect.DeepEqual([]float64{1, 3, 3, 3}, output) {
+		t.Errorf("Got %v, want [1 3 3 3]", output)
+	}
+
+	// long integer valued inputs stay exact: 200 ones with themselves give the triangle 1, 2, ..., 200, ..., 1
+	triangle := append(Arange(1.0, 201, 1), Flipud(Arange(1.0, 200, 1))...)
+	if output := Convolve(Ones(200), Ones(200), "full"); !reflect.DeepEqual(triangle, output) {
+		t.Errorf("Got %v, want %v", output, triangle)
+	}
+	large := Repeat(1e6+1, 100)
+	if output := Convolve(large, large, "valid"); !reflect.DeepEqual([]float64{100 * (1e6 + 1) * (1e6 + 1)}, output) {
+		t.Errorf("Got %v, want [%v]", output, 100*(1e6+1)*(1e6+1))
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	a, v := []float64{1, 2, 3}, []float64{0, 1, 0.5}
+	if output := Correlate(a, v, ""); !reflect.DeepEqual([]float64{3.5}, output) {
+		t.Errorf("Got %v, want [3.5]", output)
+	}
+	if output := Correlate(a, v, "full"); !reflect.DeepEqual([]float64{0.5, 2, 3.5, 3, 0}, output) {
+		t.Errorf("Got %v, want [0.5 2 3.5 3 0]", output)
+	}
+	if output := Correlate(a, v, "same"); !reflect.DeepEqual([]float64{2, 3.5, 3}, output) {
+		t.Errorf("Got %v, want [2 3.5 3]", output)
+	}
+	if output := Correlate([]float64{1, 2}, []float64{1, 2, 3}, "valid"); !reflect.DeepEqual([]float64{8, 5}, output) {
+		t.Errorf("Got %v, want [8 5]", output)
+	}
+	// numpy swaps the inputs when v is longer and reverses the result
+	if output := Correlate([]float64{1, 2}, []float64{1, 2, 3, 4}, "same"); !reflect.DeepEqual([]float64{11, 8, 5, 2}, output) {
+		t.Errorf("Got %v, want [11 8 5 2]", output)
+	}
+	if output := Correlate([]float64{1, 2}, []float64{1, 2, 3, 4}, "full"); !reflect.DeepEqual([]float64{4, 11, 8, 5, 2}, output) {
+		t.Errorf("Got %v, want [4 11 8 5 2]", output)
+	}
+}
+
+func TestFFTConvolve(t *testing.T) {
+	if output := FFTConvolve([]float64{1, 2, 3}, []float64{0, 1, 0.5}, "full"); !AllClose([]float64{0, 1, 2.5, 4, 1.5}, output, 1e-12) {
+		t.Errorf("Got %v, want [0 1 2.5 4 1.5]", output)
+	}
+	// "same" keeps the length of the first input like scipy
+	if output := FFTConvolve([]float64{1, 2}, []float64{1, 1, 1, 1}, "same"); !AllClose([]float64{3, 3}, output, 1e-12) {
+		t.Errorf("Got %v, want [3 3]", output)
+	}
+	if output := FFTConvolve([]float64{1, 2}, []float64{1, 1, 1, 1}, "valid"); !AllClose([]float64{3, 3, 3}, output, 1e-12) {
+		t.Errorf("Got %v, want [3 3 3]", output)
+	}
+
+	x := Apply(Arange(0.0, 5000, 1), func(v float64) float64 { return math.Sin(0.01*v*v) + math.Cos(v) })
+	kernel := Hann(31, true)
+	for _, mode := range []string{"full", "same", "valid"} {
+		expected := FFTConvolve(x, kernel, mode)
+		if output := OAConvolve(x, kernel, mode); !AllClose(expected, output, 1e-10) {
+			t.Errorf("OAConvolve differs from FFTConvolve in %s mode", mode)
+		}
+		if output := OAConvolve(kernel, x, mode); !AllClose(FFTConvolve(kernel, x, mode), output, 1e-10) {
+			t.Errorf("OAConvolve with swapped inputs differs from FFTConvolve in %s mode", mode)
+		}
+	}
+}
+
+func TestConvolve2D(t *testing.T) {
+	in1, in2 := [][]float64{{1, 2}, {3, 4}}, [][]float64{{1, 1}, {1, 1}}
+	if output := Convolve2D(in1, in2, ""); !reflect.DeepEqual([][]float64{{1, 3, 2}, {4, 10, 6}, {3, 7, 4}}, output) {
+		t.Errorf("Got %v", output)
+	}
+	if output := Convolve2D(in1, in2, "same"); !reflect.DeepEqual([][]float64{{1, 3}, {4, 10}}, output) {
+		t.Errorf("Got %v, want [[1 3] [4 10]]", output)
+	}
+	if output := Convolve2D(in2, [][]float64{{5}}, "valid"); !reflect.DeepEqual([][]float64{{5, 5}, {5, 5}}, output) {
+		t.Errorf("Got %v, want [[5 5] [5 5]]", output)
+	}
+	if output := Correlate2D(in1, in1, "full"); !reflect.DeepEqual([][]float64{{4, 11, 6}, {14, 30, 14}, {6, 11, 4}}, output) {
+		t.Errorf("Got %v", output)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Convolve2D in valid mode with incompatible shapes did not panic")
+		}
+	}()
+	Convolve2D([][]float64{{1, 2, 3}}, [][]float64{{1}, {2}}, "valid")
+}
+
+func TestCorrelationLags(t *testing.T) {
+	if output := CorrelationLags(5, 3, "full"); !reflect.DeepEqual([]int{-2, -1, 0, 1, 2, 3, 4}, output) {
+		t.Errorf("Got %v, want [-2 -1 0 1 2 3 4]", output)
+	}
+	if output := CorrelationLags(5, 3, "same"); !reflect.DeepEqual([]int{-1, 0, 1, 2, 3}, output) {
+		t.Errorf("Got %v, want [-1 0 1 2 3]", output)
+	}
+	if output := CorrelationLags(5, 3, "valid"); !reflect.DeepEqual([]int{0, 1, 2}, output) {
+		t.Errorf("Got %v, want [0 1 2]", output)
+	}
+	if output := CorrelationLags(3, 5, "valid"); !reflect.DeepEqual([]int{-2, -1, 0}, output) {
+		t.Errorf("Got %v, want [-2 -1 0]", output)
+	}
+
+	// the peak of the correlation gives the delay of a shifted copy
+	x := Apply(Arange(0.0, 200, 1), func(v float64) float64 { return math.Sin(0.05 * v * v) })
+	delayed := append(make([]float64, 7), x[:193]...)
+	correlation := Correlate(delayed, x, "full")
+	_, peak := Max(correlation)
+	if lag := CorrelationLags(len(delayed), len(x), "full")[peak]; lag != 7 {
+		t.Errorf("Got lag %d, want 7", lag)
+	}
+}
@@ -0,0 +1,20 @@
+package vectors
+
+import "errors"
+
+// Sentinel errors returned, usually wrapped with details, by the Try variants of the functions that panic
+// on invalid input. Test for them with errors.Is.
+var (
+	// ErrShapeMismatch reports operands whose lengths or shapes are incompatible.
+	ErrShapeMismatch = errors.New("shape mismatch")
+	// ErrEmpty reports an input without the elements the operation needs.
+	ErrEmpty = errors.New("empty input")
+	// ErrSingular reports a matrix that cannot be inverted or factorized.
+	ErrSingular = errors.New("singular matrix")
+	// ErrIndexOutOfRange reports an index outside the bounds of its slice.
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// ErrNotPositiveDefinite reports a matrix without a Cholesky decomposition.
+	ErrNotPositiveDefinite = errors.New("matrix is not positive definite")
+	// ErrNotConverged reports an iterative algorithm that did not converge.
+	ErrNotConverged = errors.New("did not converge")
+)
@@ -0,0 +1,517 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// bluesteinThreshold is the length above which prime factors of a transform use Bluestein's algorithm
+// instead of a direct O(p^2) DFT
+const bluesteinThreshold = 16
+
+// FFT returns the discrete Fourier transform of x like numpy.fft.fft. x is cropped or zero-padded to n
+// points, n <= 0 meaning len(x). norm is one of "backward" (or ""), "ortho" and "forward" and selects
+// whether the forward transform is unscaled, scaled by 1/sqrt(n) or scaled by 1/n. Any length is handled
+// in O(n log n) by a mixed-radix algorithm, whose prime factors above 16 are transformed with Bluestein's
+// algorithm.
+func FFT(x []complex128, n int, norm string) []complex128 {
+	x = resizeComplex(x, n)
+	result := fftRecursive(x, -1)
+	scaleComplex(result, fftScale("fft", len(x), norm, false))
+	return result
+}
+
+// IFFT returns the inverse discrete Fourier transform of x like numpy.fft.ifft, so that IFFT(FFT(x)) is x
+// for the same norm. n and norm are as in FFT, with "backward" scaling the inverse transform by 1/n.
+func IFFT(x []complex128, n int, norm string) []complex128 {
+	x = resizeComplex(x, n)
+	result := fftRecursive(x, 1)
+	scaleComplex(result, fftScale("ifft", len(x), norm, true))
+	return result
+}
+
+// RFFT returns the non-negative frequency terms, n/2+1 of them, of the discrete Fourier transform of the
+// real signal x like numpy.fft.rfft. n and norm are as in FFT.
+func RFFT(x []float64, n int, norm string) []complex128 {
+	if n <= 0 {
+		n = len(x)
+	}
+	return FFT(toComplex(x), n, norm)[:n/2+1]
+}
+
+// IRFFT returns the real signal of n points whose non-negative frequency terms are x, inverting RFFT like
+// numpy.fft.irfft. n <= 0 means 2*(len(x)-1), terms beyond n/2 are ignored and missing ones taken as zero.
+// The imaginary parts of the zero frequency and, for even n, the Nyquist terms are ignored.
+func IRFFT(x []complex128, n int, norm string) []float64 {
+	if n <= 0 {
+		n = 2 * (len(x) - 1)
+	}
+	if n <= 0 {
+		panic(fmt.Sprintf("irfft: invalid number of data points %d", n))
+	}
+	half := resizeComplex(x, n/2+1)
+	full := make([]complex128, n)
+	full[0] = complex(real(half[0]), 0)
+	for k := 1; k <= n/2; k++ {
+		full[k] = half[k]
+		full[n-k] = cmplx.Conj(half[k])
+	}
+	if n%2 == 0 {
+		full[n/2] = complex(real(half[n/2]), 0)
+	}
+	return Real(IFFT(full, n, norm))
+}
+
+// FFTFreq returns the sample frequencies of the terms of an n point FFT with sample spacing d, the
+// positive frequencies first followed by the negative ones like numpy.fft.fftfreq.
+func FFTFreq(n int, d float64) []float64 {
+	result := make([]float64, n)
+	for i := range result {
+		k := i
+		if i > (n-1)/2 {
+			k = i - n
+		}
+		result[i] = float64(k) / (d * float64(n))
+	}
+	return result
+}
+
+// RFFTFreq returns the sample frequencies of the n/2+1 terms of an n point RFFT with sample spacing d like
+// numpy.fft.rfftfreq.
+func RFFTFreq(n int, d float64) []float64 {
+	result := make([]float64, n/2+1)
+	for i := range result {
+		result[i] = float64(i) / (d * float64(n))
+	}
+	return result
+}
+
+// FFTShift returns x rotated so that the zero frequency term is in the middle, as numpy.fft.fftshift does
+// for a 1-D spectrum.
+func FFTShift[T any](x []T) []T {
+	return rotate(x, len(x)/2)
+}
+
+// IFFTShift is the inverse of FFTShift, the two differ for odd lengths.
+func IFFTShift[T any](x []T) []T {
+	return rotate(x, -(len(x) / 2))
+}
+
+// rotate returns a copy of x with its elements moved shift positions to the right, wrapping around
+func rotate[T any](x []T, shift int) []T {
+	n := len(x)
+	result := make([]T, n)
+	for i, v := range x {
+		result[((i+shift)%n+n)%n] = v
+	}
+	return result
+}
+
+// toComplex returns a real slice converted to complex values
+func toComplex(x []float64) []complex128 {
+	result := make([]complex128, len(x))
+	for i, v := range x {
+		result[i] = complex(v, 0)
+	}
+	return result
+}
+
+// resizeComplex returns a copy of x cropped or zero-padded to n values, n <= 0 keeping its length
+func resizeComplex(x []complex128, n int) []complex128 {
+	if n <= 0 {
+		n = len(x)
+	}
+	if n == 0 {
+		panic("fft: invalid number of data points 0")
+	}
+	result := make([]complex128, n)
+	copy(result, x)
+	return result
+}
+
+// scaleComplex multiplies x by factor in place
+func scaleComplex(x []complex128, factor float64) {
+	if factor == 1 {
+		return
+	}
+	for i := range x {
+		x[i] *= complex(factor, 0)
+	}
+}
+
+// fftScale returns the factor applying the norm mode to an n point forward or inverse transform
+func fftScale(name string, n int, norm string, inverse bool) float64 {
+	switch norm {
+	case "", "backward":
+		if inverse {
+			return 1 / float64(n)
+		}
+	case "ortho":
+		return 1 / math.Sqrt(float64(n))
+	case "forward":
+		if !inverse {
+			return 1 / float64(n)
+		}
+	default:
+		panic(fmt.Sprintf("%s: invalid norm value %q, should be \"backward\", \"ortho\" or \"forward\"", name, norm))
+	}
+	return 1
+}
+
+// fftPlan holds the twiddle factors of an n point transform in the direction sign, shared by all levels
+// of the mixed-radix recursion since the length of every sub-transform divides n
+type fftPlan struct {
+	n    int
+	sign float64
+	// w[k] = exp(sign * 2 pi i k / n), so that the twiddles of a sub-transform of length n/stride are
+	// w[k*stride]
+	w []complex128
+	// chirps holds the Bluestein plans of the prime factors above bluesteinThreshold
+	chirps map[int]*bluesteinPlan
+}
+
+// newFFTPlan returns the plan of an n point transform in the direction sign
+func newFFTPlan(n int, sign float64) *fftPlan {
+	w := make([]complex128, n)
+	for k := range w {
+		sin, cos := math.Sincos(2 * math.Pi * float64(k) / float64(n))
+		w[k] = complex(cos, sign*sin)
+	}
+	return &fftPlan{n: n, sign: sign, w: w, chirps: map[int]*bluesteinPlan{}}
+}
+
+// fftRecursive returns the unscaled transform sum_j x[j] exp(sign * 2 pi i j k / n)
+func fftRecursive(x []complex128, sign float64) []complex128 {
+	return newFFTPlan(len(x), sign).transform(x, 1)
+}
+
+// transform returns the transform of x, whose length is plan.n/stride. Powers of two use radix2, other
+// lengths are split by their smallest prime factor p into p interleaved transforms of length q = n/p,
+// combined by p-point transforms of the sub-results multiplied by twiddle factors. Prime factors up to
+// bluesteinThreshold are combined directly and larger ones with Bluestein's algorithm, so that every
+// length costs O(n log n).
+func (plan *fftPlan) transform(x []complex128, stride int) []complex128 {
+	n := len(x)
+	if n&(n-1) == 0 {
+		return plan.radix2(x, stride)
+	}
+	p := smallestFactor(n)
+	if p == n {
+		return plan.prime(x, stride)
+	}
+	q := n / p
+
+	// transform the p subsequences x[r], x[r+p], ...
+	sub := make([][]complex128, p)
+	part := make([]complex128, q)
+	for r := 0; r < p; r++ {
+		for j := 0; j < q; j++ {
+			part[j] = x[j*p+r]
+		}
+		sub[r] = plan.transform(part, stride*p)
+	}
+
+	result := make([]complex128, n)
+	if p <= bluesteinThreshold {
+		for k := range result {
+			var sum complex128
+			for r := 0; r < p; r++ {
+				sum += plan.w[r*k%n*stride] * sub[r][k%q]
+			}
+			result[k] = sum
+		}
+		return result
+	}
+	// X[k + q s] = sum_r exp(sign 2 pi i r s / p) (exp(sign 2 pi i r k / n) sub[r][k]), a p-point
+	// transform for each k
+	column := make([]complex128, p)
+	for k := 0; k < q; k++ {
+		for r := 0; r < p; r++ {
+			column[r] = plan.w[r*k*stride] * sub[r][k]
+		}
+		for s, v := range plan.prime(column, stride*q) {
+			result[k+q*s] = v
+		}
+	}
+	return result
+}
+
+// radix2 returns the transform of x, whose length plan.n/stride is a power of two, with the iterative
+// in-place radix-2 algorithm
+func (plan *fftPlan) radix2(x []complex128, stride int) []complex128 {
+	n := len(x)
+	result := make([]complex128, n)
+	// copy x in bit-reversed order
+	for i, j := 0, 0; i < n; i++ {
+		result[j] = x[i]
+		bit := n >> 1
+		for ; bit > 0 && j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size*stride
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				t := plan.w[k*step] * result[start+k+half]
+				result[start+k+half] = result[start+k] - t
+				result[start+k] += t
+			}
+		}
+	}
+	return result
+}
+
+// prime returns the transform of x, of prime length plan.n/stride, directly or, above
+// bluesteinThreshold, with Bluestein's algorithm
+func (plan *fftPlan) prime(x []complex128, stride int) []complex128 {
+	n := len(x)
+	if n > bluesteinThreshold {
+		chirp, ok := plan.chirps[n]
+		if !ok {
+			chirp = newBluesteinPlan(n, plan.sign)
+			plan.chirps[n] = chirp
+		}
+		return chirp.transform(x)
+	}
+	result := make([]complex128, n)
+	for k := range result {
+		var sum complex128
+		for j, v := range x {
+			sum += plan.w[j*k%n*stride] * v
+		}
+		result[k] = sum
+	}
+	return result
+}
+
+// smallestFactor returns the smallest prime factor of n > 1
+func smallestFactor(n int) int {
+	if n%2 == 0 {
+		return 2
+	}
+	for p := 3; p*p <= n; p += 2 {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+// bluesteinPlan computes the transform of an n point fftPlan as a circular convolution of a power-of-two
+// length m, using j k = (j^2 + k^2 - (k-j)^2) / 2 to turn the DFT into a chirp filter
+type bluesteinPlan struct {
+	// chirp[j] = exp(sign * pi i j^2 / n)
+	chirp []complex128
+	// filter is the transform of the conjugate chirp wrapped around a length of m
+	filter           []complex128
+	forward, inverse *fftPlan
+}
+
+// newBluesteinPlan returns the Bluestein plan of an n point transform in the direction sign
+func newBluesteinPlan(n int, sign float64) *bluesteinPlan {
+	m := 1
+	for m < 2*n-1 {
+		m *= 2
+	}
+	chirp := make([]complex128, n)
+	for j := range chirp {
+		// j^2 is reduced modulo 2n for accuracy
+		sin, cos := math.Sincos(math.Pi * float64(int64(j)*int64(j)%int64(2*n)) / float64(n))
+		chirp[j] = complex(cos, sign*sin)
+	}
+	b := make([]complex128, m)
+	for j := 0; j < n; j++ {
+		b[j] = cmplx.Conj(chirp[j])
+		if j > 0 {
+			b[m-j] = b[j]
+		}
+	}
+	forward := newFFTPlan(m, -1)
+	return &bluesteinPlan{chirp: chirp, filter: forward.transform(b, 1), forward: forward, inverse: newFFTPlan(m, 1)}
+}
+
+// transform returns the transform of x
+func (plan *bluesteinPlan) transform(x []complex128) []complex128 {
+	n, m := len(plan.chirp), len(plan.filter)
+	a := make([]complex128, m)
+	for j := 0; j < n; j++ {
+		a[j] = x[j] * plan.chirp[j]
+	}
+	fa := plan.forward.transform(a, 1)
+	for i := range fa {
+		fa[i] *= plan.filter[i]
+	}
+	conv := plan.inverse.transform(fa, 1)
+	result := make([]complex128, n)
+	for k := range result {
+		result[k] = plan.chirp[k] * conv[k] / complex(float64(m), 0)
+	}
+	return result
+}
+
+// FFT2 returns the 2-D discrete Fourier transform of the matrix x like numpy.fft.fft2, transforming it
+// along each of axes in turn. axes defaults to numpy's (-2, -1), both axes, when nil, and negative axes
+// count from the end. norm is as in FFT.
+func FFT2(x [][]complex128, axes []int, norm string) [][]complex128 {
+	data, shape := flattenComplex("fft2", x)
+	return unflattenComplex(FFTN(data, shape, axes, norm))
+}
+
+// IFFT2 returns the 2-D inverse discrete Fourier transform of the matrix x like numpy.fft.ifft2, with the
+// arguments of FFT2.
+func IFFT2(x [][]complex128, axes []int, norm string) [][]complex128 {
+	data, shape := flattenComplex("ifft2", x)
+	return unflattenComplex(IFFTN(data, shape, axes, norm))
+}
+
+// RFFT2 returns the 2-D discrete Fourier transform of the real matrix x like numpy.fft.rfft2. The last of
+// axes, the rows by default, is transformed with RFFT, keeping its non-negative frequency terms. axes and
+// norm are as in FFT2.
+func RFFT2(x [][]float64, axes []int, norm string) [][]complex128 {
+	array := NDFrom2D(x)
+	return unflattenComplex(RFFTN(array.Flatten(), array.Shape(), axes, norm))
+}
+
+// FFTN returns the N-D discrete Fourier transform like numpy.fft.fftn of the array of the given shape
+// whose elements x holds in row-major order, the layout of NDArray, along with the shape of the result.
+// The 1-D transform is applied along each of axes in turn, all axes when axes is nil, and negative axes
+// count from the end. norm is as in FFT and applies to the transform as a whole.
+func FFTN(x []complex128, shape []int, axes []int, norm string) ([]complex128, []int) {
+	return transformAxes("fftn", x, shape, axes, func(line []complex128) []complex128 {
+		return FFT(line, 0, norm)
+	}), append([]int{}, shape...)
+}
+
+// IFFTN returns the N-D inverse discrete Fourier transform like numpy.fft.ifftn, with the arguments and
+// results of FFTN.
+func IFFTN(x []complex128, shape []int, axes []int, norm string) ([]complex128, []int) {
+	return transformAxes("ifftn", x, shape, axes, func(line []complex128) []complex128 {
+		return IFFT(line, 0, norm)
+	}), append([]int{}, shape...)
+}
+
+// RFFTN returns the N-D discrete Fourier transform of a real array like numpy.fft.rfftn, with the
+// arguments and results of FFTN. The last of axes is transformed with RFFT, keeping its non-negative
+// frequency terms, and the others with FFT.
+func RFFTN(x []float64, shape []int, axes []int, norm string) ([]complex128, []int) {
+	checkFFTShape("rfftn", len(x), shape)
+	data := toComplex(x)
+	axes = checkFFTAxes("rfftn", shape, axes)
+	if len(axes) == 0 {
+		return data, append([]int{}, shape...)
+	}
+	last := axes[len(axes)-1]
+	data, shape = transformAxis(data, shape, last, func(line []complex128) []complex128 {
+		return RFFT(Real(line), 0, norm)
+	})
+	return FFTN(data, shape, axes[:len(axes)-1], norm)
+}
+
+// checkFFTAxes returns the axes counted from the front, or all axes when axes is nil, panicking when one
+// is out of range or repeated
+func checkFFTAxes(name string, shape []int, axes []int) []int {
+	ndim := len(shape)
+	if axes == nil {
+		axes = make([]int, ndim)
+		for i := range axes {
+			axes[i] = i
+		}
+		return axes
+	}
+	result := make([]int, len(axes))
+	seen := make([]bool, ndim)
+	for i, axis := range axes {
+		if axis < 0 {
+			axis += ndim
+		}
+		if axis < 0 || axis >= ndim || seen[axis] {
+			panic(fmt.Sprintf("%s: invalid axes %v for an array with %d dimensions", name, axes, ndim))
+		}
+		seen[axis] = true
+		result[i] = axis
+	}
+	return result
+}
+
+// checkFFTShape panics unless an array of the given shape has n elements
+func checkFFTShape(name string, n int, shape []int) {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	if size != n {
+		panic(fmt.Sprintf("%s: cannot use %d values as an array of shape %v", name, n, shape))
+	}
+}
+
+// transformAxes applies transform to every line of x along each of axes in turn
+func transformAxes(name string, x []complex128, shape []int, axes []int, transform func([]complex128) []complex128) []complex128 {
+	checkFFTShape(name, len(x), shape)
+	result := append([]complex128{}, x...)
+	for _, axis := range checkFFTAxes(name, shape, axes) {
+		result, _ = transformAxis(result, shape, axis, transform)
+	}
+	return result
+}
+
+// transformAxis applies transform to every line of the row-major array x along axis, returning the
+// result and its shape, in which the length of axis is that of the transformed lines
+func transformAxis(x []complex128, shape []int, axis int, transform func([]complex128) []complex128) ([]complex128, []int) {
+	strides := rowMajorStrides(shape)
+	inner := strides[axis]
+	outer := 1
+	for _, dim := range shape[:axis] {
+		outer *= dim
+	}
+	n := shape[axis]
+	newShape := append([]int{}, shape...)
+	var result []complex128
+	line := make([]complex128, n)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			start := o*n*inner + i
+			for k := range line {
+				line[k] = x[start+k*inner]
+			}
+			out := transform(line)
+			if result == nil {
+				newShape[axis] = len(out)
+				result = make([]complex128, outer*len(out)*inner)
+			}
+			for k, v := range out {
+				result[o*len(out)*inner+i+k*inner] = v
+			}
+		}
+	}
+	if result == nil {
+		return []complex128{}, newShape
+	}
+	return result, newShape
+}
+
+// flattenComplex returns the elements of the matrix x in row-major order with its shape
+func flattenComplex(name string, x [][]complex128) ([]complex128, []int) {
+	var data []complex128
+	for _, row := range x {
+		if len(row) != len(x[0]) {
+			panic(fmt.Sprintf("%s: rows must have the same length", name))
+		}
+		data = append(data, row...)
+	}
+	if len(x) == 0 {
+		return data, []int{0, 0}
+	}
+	return data, []int{len(x), len(x[0])}
+}
+
+// unflattenComplex returns the rows of the row-major matrix data of the given shape
+func unflattenComplex(data []complex128, shape []int) [][]complex128 {
+	result := make([][]complex128, shape[0])
+	for i := range result {
+		result[i] = data[i*shape[1] : (i+1)*shape[1] : (i+1)*shape[1]]
+	}
+	return result
+}
@@ -0,0 +1,257 @@
+package vectors
+
+import (
+	"math"
+	"math/cmplx"
+	"reflect"
+	"testing"
+)
+
+// naiveDFT returns the O(n^2) discrete Fourier transform of x
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	result := make([]complex128, n)
+	for k := range result {
+		for j, v := range x {
+			result[k] += v * cmplx.Exp(complex(0, -2*math.Pi*float64(j*k%n)/float64(n)))
+		}
+	}
+	return result
+}
+
+// complexClose reports whether two complex slices have the same length and elements within tol
+func complexClose(a, b []complex128, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmplx.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFFT(t *testing.T) {
+	expected := []complex128{10, -2 + 2i, -2, -2 - 2i}
+	if output := FFT([]complex128{1, 2, 3, 4}, 0, ""); !complexClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	// padding and cropping to n points
+	if output := FFT([]complex128{1, 2}, 4, "backward"); !complexClose([]complex128{3, 1 - 2i, -1, 1 + 2i}, output, 1e-12) {
+		t.Errorf("Got %v, want [3 1-2i -1 1+2i]", output)
+	}
+	if output := FFT([]complex128{1, 2, 3, 4}, 2, ""); !complexClose([]complex128{3, -1}, output, 1e-12) {
+		t.Errorf("Got %v, want [3 -1]", output)
+	}
+
+	// powers of two, mixed radices, prime lengths and large prime factors handled by Bluestein's algorithm
+	for _, n := range []int{1, 2, 3, 5, 6, 12, 15, 17, 30, 97, 106, 210, 256, 323, 1009, 1334} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i*i)), math.Cos(float64(3*i)))
+		}
+		if output := FFT(x, 0, ""); !complexClose(naiveDFT(x), output, 1e-9*float64(n)) {
+			t.Errorf("FFT of length %d differs from the DFT", n)
+		}
+		if output := IFFT(FFT(x, 0, ""), 0, ""); !complexClose(x, output, 1e-12*float64(n)) {
+			t.Errorf("IFFT of length %d does not invert FFT", n)
+		}
+	}
+
+	// a length with two large prime factors transforms as accurately as a power of two
+	x := make([]complex128, 509*521)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i%1000)), 0)
+	}
+	if output := IFFT(FFT(x, 0, ""), 0, ""); !complexClose(x, output, 1e-12) {
+		t.Errorf("IFFT of length %d does not invert FFT", len(x))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FFT with an invalid norm did not panic")
+		}
+	}()
+	FFT([]complex128{1}, 0, "none")
+}
+
+func TestFFTNorm(t *testing.T) {
+	x := []complex128{1, 2i, -3, 4}
+	backward := FFT(x, 0, "backward")
+	for i, v := range FFT(x, 0, "ortho") {
+		if cmplx.Abs(v-backward[i]/2) > 1e-12 {
+			t.Errorf("ortho FFT is not scaled by 1/sqrt(n)")
+		}
+	}
+	for i, v := range FFT(x, 0, "forward") {
+		if cmplx.Abs(v-backward[i]/4) > 1e-12 {
+			t.Errorf("forward FFT is not scaled by 1/n")
+		}
+	}
+	for _, norm := range []string{"backward", "ortho", "forward"} {
+		if output := IFFT(FFT(x, 0, norm), 0, norm); !complexClose(x, output, 1e-12) {
+			t.Errorf("IFFT with norm %s does not invert FFT: %v", norm, output)
+		}
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	expected := []complex128{10, -2 + 2i, -2}
+	if output := RFFT([]float64{1, 2, 3, 4}, 0, ""); !complexClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	for _, n := range []int{7, 8} {
+		x := Arange(0, float64(n), 1)
+		x[2] = -5
+		spectrum := RFFT(x, 0, "ortho")
+		if len(spectrum) != n/2+1 {
+			t.Errorf("Got %d terms for %d points", len(spectrum), n)
+		}
+		if output := IRFFT(spectrum, n, "ortho"); !AllClose(x, output, 1e-12) {
+			t.Errorf("Got %v, want %v", output, x)
+		}
+	}
+	// the default length is 2*(m-1)
+	if output := IRFFT([]complex128{10, -2 + 2i, -2}, 0, ""); !AllClose([]float64{1, 2, 3, 4}, output, 1e-12) {
+		t.Errorf("Got %v, want [1 2 3 4]", output)
+	}
+}
+
+func TestFFTFreq(t *testing.T) {
+	expected := []float64{0, 1.25, 2.5, 3.75, -5, -3.75, -2.5, -1.25}
+	if output := FFTFreq(8, 0.1); !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := FFTFreq(5, 1); !AllClose([]float64{0, 0.2, 0.4, -0.4, -0.2}, output, 1e-12) {
+		t.Errorf("Got %v, want [0 0.2 0.4 -0.4 -0.2]", output)
+	}
+	if output := RFFTFreq(9, 0.5); !AllClose([]float64{0, 2.0 / 9, 4.0 / 9, 6.0 / 9, 8.0 / 9}, output, 1e-12) {
+		t.Errorf("Got %v", output)
+	}
+}
+
+func TestFFTShift(t *testing.T) {
+	freqs := FFTFreq(10, 0.1)
+	expected := []float64{-5, -4, -3, -2, -1, 0, 1, 2, 3, 4}
+	if output := FFTShift(freqs); !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	odd := []int{0, 1, 2, -2, -1}
+	if output := FFTShift(odd); !reflect.DeepEqual([]int{-2, -1, 0, 1, 2}, output) {
+		t.Errorf("Got %v, want [-2 -1 0 1 2]", output)
+	}
+	if output := IFFTShift(FFTShift(odd)); !reflect.DeepEqual(odd, output) {
+		t.Errorf("Got %v, want %v", output, odd)
+	}
+}
+
+func TestFFT2(t *testing.T) {
+	expected := [][]complex128{{10, -2}, {-4, 0}}
+	output := FFT2([][]complex128{{1, 2}, {3, 4}}, nil, "")
+	if len(output) != 2 || !complexClose(expected[0], output[0], 1e-12) || !complexClose(expected[1], output[1], 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	x := [][]complex128{{1, 2i, 3}, {-1, 0, 5 - 1i}}
+	for i, row := range IFFT2(FFT2(x, nil, "ortho"), []int{-2, -1}, "ortho") {
+		if !complexClose(x[i], row, 1e-12) {
+			t.Errorf("Got %v, want %v", row, x[i])
+		}
+	}
+	// a single axis transforms the rows or the columns alone
+	for i, row := range FFT2(x, []int{-1}, "") {
+		if !complexClose(naiveDFT(x[i]), row, 1e-12) {
+			t.Errorf("Got %v, want %v", row, naiveDFT(x[i]))
+		}
+	}
+	columns := FFT2(x, []int{0}, "")
+	for j := range x[0] {
+		column := naiveDFT([]complex128{x[0][j], x[1][j]})
+		if !complexClose(column, []complex128{columns[0][j], columns[1][j]}, 1e-12) {
+			t.Errorf("Got column %v, want %v", []complex128{columns[0][j], columns[1][j]}, column)
+		}
+	}
+
+	real2 := RFFT2([][]float64{{1, 2, 3}, {4, 5, 6}}, nil, "")
+	expected = [][]complex128{{21, complex(-3, math.Sqrt(3))}, {-9, 0}}
+	if len(real2) != 2 || !complexClose(expected[0], real2[0], 1e-12) || !complexClose(expected[1], real2[1], 1e-12) {
+		t.Errorf("Got %v, want %v", real2, expected)
+	}
+	// with the axes swapped the columns keep their non-negative frequencies
+	realX := [][]float64{{1, 2, 3}, {4, 5, 6}, {0, -1, 2}, {7, 1, 1}}
+	full := FFT2([][]complex128{toComplex(realX[0]), toComplex(realX[1]), toComplex(realX[2]), toComplex(realX[3])}, nil, "")
+	real2 = RFFT2(realX, []int{1, 0}, "")
+	if len(real2) != 3 {
+		t.Fatalf("Got %d rows, want 3", len(real2))
+	}
+	for i, row := range real2 {
+		if !complexClose(full[i], row, 1e-12) {
+			t.Errorf("Got %v, want %v", row, full[i])
+		}
+	}
+}
+
+func TestFFTN(t *testing.T) {
+	shape := []int{2, 3, 4}
+	x := make([]complex128, 24)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i)), float64(i%5))
+	}
+	// transforming axes 0 and 2 equals the 1-D transform of each line along them
+	expected := append([]complex128{}, x...)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			line := expected[i*12+j*4 : i*12+j*4+4]
+			copy(line, naiveDFT(line))
+		}
+	}
+	for j := 0; j < 3; j++ {
+		for k := 0; k < 4; k++ {
+			line := naiveDFT([]complex128{expected[j*4+k], expected[12+j*4+k]})
+			expected[j*4+k], expected[12+j*4+k] = line[0], line[1]
+		}
+	}
+	output, outShape := FFTN(x, shape, []int{0, -1}, "")
+	if !complexClose(expected, output, 1e-12) || !reflect.DeepEqual(shape, outShape) {
+		t.Errorf("Got %v with shape %v, want %v", output, outShape, expected)
+	}
+	spectrum, _ := FFTN(x, shape, nil, "forward")
+	if output, _ := IFFTN(spectrum, shape, nil, "forward"); !complexClose(x, output, 1e-12) {
+		t.Errorf("IFFTN does not invert FFTN")
+	}
+
+	// RFFTN keeps the non-negative frequencies of the last transformed axis of the full transform
+	array := NewNDArray(Real(x), 2, 3, 4)
+	for _, c := range []struct {
+		axes  []int
+		shape []int
+	}{
+		{nil, []int{2, 3, 3}},
+		{[]int{2, 0}, []int{2, 3, 4}},
+		{[]int{0, 2, 1}, []int{2, 2, 4}},
+	} {
+		full, _ := FFTN(toComplex(array.Flatten()), shape, c.axes, "")
+		spectrum, newShape := RFFTN(array.Flatten(), array.Shape(), c.axes, "")
+		if !reflect.DeepEqual(c.shape, newShape) {
+			t.Errorf("RFFTN along %v: got shape %v, want %v", c.axes, newShape, c.shape)
+			continue
+		}
+		expected := make([]complex128, 0, len(spectrum))
+		for i := 0; i < newShape[0]; i++ {
+			for j := 0; j < newShape[1]; j++ {
+				expected = append(expected, full[i*12+j*4:i*12+j*4+newShape[2]]...)
+			}
+		}
+		if !complexClose(expected, spectrum, 1e-12) {
+			t.Errorf("RFFTN along %v: got %v, want %v", c.axes, spectrum, expected)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FFTN with a repeated axis did not panic")
+		}
+	}()
+	FFTN(x, shape, []int{1, -2}, "")
+}
@@ -2,6 +2,7 @@ package vectors
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -16,7 +17,7 @@ func TabToSpace(input string) string {
 		// all these considered as space, including tab \t
 		// '\t', '\n', '\v', '\f', '\r',' ', 0x85, 0xA0
 		case unicode.IsSpace(i):
-			if result[len(result)-1] != " " {
+			if len(result) > 0 && result[len(result)-1] != " " {
 				result = append(result, " ") // replace tab with space
 			}
 		case !unicode.IsSpace(i):
@@ -26,29 +27,52 @@ func TabToSpace(input string) string {
 	return strings.Join(result, "")
 }
 
-// Loadtxt returns a slice of float64s from a file.
+// Loadtxt returns a slice of float64s from a file. Tokens that are not numbers, including the empty token
+// of a blank line, are read as 0.
 func Loadtxt(filepath string, start int, unpack bool) [][]float64 {
-	f, err := os.Open(filepath)
-	result := [][]float64{{}}
-
+	result, err := loadtxt(filepath, start, unpack, false)
 	if err != nil {
 		panic(err)
 	}
+	return result
+}
 
+// TryLoadtxt is Loadtxt returning the errors of opening, reading or parsing the file instead of panicking.
+// Unlike Loadtxt it skips blank lines and reports tokens that are not numbers.
+func TryLoadtxt(filepath string, start int, unpack bool) ([][]float64, error) {
+	return loadtxt(filepath, start, unpack, true)
+}
+
+// loadtxt reads the file for Loadtxt and, when strict, for TryLoadtxt
+func loadtxt(filepath string, start int, unpack bool, strict bool) (result [][]float64, err error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
 	defer func(f *os.File) {
-		err := f.Close()
-		if err != nil {
-			panic(err)
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			result, err = nil, closeErr
 		}
 	}(f)
 
+	result = [][]float64{{}}
 	scanner := bufio.NewScanner(f)
 	i := 0
 	for scanner.Scan() {
 		if i >= start {
 			line := TabToSpace(scanner.Text())
+			if strict {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					i++
+					continue
+				}
+			}
 			for colIndex, c := range strings.Split(line, " ") {
-				s, _ := strconv.ParseFloat(c, 64)
+				s, err := strconv.ParseFloat(c, 64)
+				if err != nil && strict {
+					return nil, fmt.Errorf("loadtxt: line %d column %d: %w", i+1, colIndex+1, err)
+				}
 				if unpack {
 					if len(result) <= colIndex {
 						result = append(result, []float64{})
@@ -61,6 +85,9 @@ func Loadtxt(filepath string, start int, unpack bool) [][]float64 {
 		}
 		i++
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
-	return result
+	return result, nil
 }
@@ -1,6 +1,9 @@
 package vectors
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -25,4 +28,42 @@ func TestLoadtxt(t *testing.T) {
 	if reflect.DeepEqual(expected3, output3) != true {
 		t.Errorf("Got %v, want %v", output3, expected3)
 	}
+
+	// a header and a blank line read as zeros instead of failing
+	path := filepath.Join(t.TempDir(), "header.txt")
+	if err := os.WriteFile(path, []byte("start=0\n1 2\n\n3 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if output := Loadtxt(path, 0, false)[0]; reflect.DeepEqual([]float64{0, 1, 2, 0, 3, 4}, output) != true {
+		t.Errorf("Got %v, want [0 1 2 0 3 4]", output)
+	}
+	if output := Loadtxt(path, 1, true); reflect.DeepEqual([][]float64{{1, 0, 3}, {2, 4}}, output) != true {
+		t.Errorf("Got %v, want [[1 0 3] [2 4]]", output)
+	}
+}
+
+func TestTryLoadtxt(t *testing.T) {
+	if _, err := TryLoadtxt("testdata/missing.txt", 0, false); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Got %v, want %v", err, os.ErrNotExist)
+	}
+
+	path := filepath.Join(t.TempDir(), "malformed.txt")
+	if err := os.WriteFile(path, []byte("1 2\n3 x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryLoadtxt(path, 0, true); err == nil {
+		t.Errorf("TryLoadtxt of a malformed record returned no error")
+	}
+	// blank lines are skipped
+	path = filepath.Join(t.TempDir(), "blank.txt")
+	if err := os.WriteFile(path, []byte("1 2\n\n3 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := TryLoadtxt(path, 0, true); err != nil || reflect.DeepEqual([][]float64{{1, 3}, {2, 4}}, output) != true {
+		t.Errorf("Got %v, %v, want [[1 3] [2 4]]", output, err)
+	}
+	output, err := TryLoadtxt("testdata/single_column.txt", 0, false)
+	if err != nil || reflect.DeepEqual([]float64{1, 2, 3, 4, 5, 6, 7}, output[0]) != true {
+		t.Errorf("Got %v, %v, want [[1 2 3 4 5 6 7]]", output, err)
+	}
 }
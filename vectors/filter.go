@@ -0,0 +1,915 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// Filter filters data along one dimension with the IIR or FIR filter defined by the numerator (b) and
+// denominator (a) coefficients, starting from zero initial conditions.
+func Filter(b []float64, a []float64, data []float64) []float64 {
+	y, _ := FilterWithState(b, a, data, nil)
+	return y
+}
+
+// FilterWithState filters data with the filter defined by b and a using a direct form II transposed
+// structure. zi holds the initial delay values and must have max(len(a), len(b)) - 1 elements, or be nil
+// for zero initial conditions. The filtered signal and the final delay values are returned so that
+// consecutive chunks of a stream can be filtered continuously.
+func FilterWithState(b []float64, a []float64, data []float64, zi []float64) ([]float64, []float64) {
+	b, a = normalizeFilter("lfilter", b, a)
+	n := len(a)
+	z := make([]float64, n-1)
+	if zi != nil {
+		if len(zi) != n-1 {
+			panic(fmt.Sprintf("lfilter: zi must have %d elements", n-1))
+		}
+		copy(z, zi)
+	}
+
+	y := make([]float64, len(data))
+	for i, x := range data {
+		if n == 1 {
+			y[i] = b[0] * x
+			continue
+		}
+		y[i] = b[0]*x + z[0]
+		for j := 0; j < n-2; j++ {
+			z[j] = b[j+1]*x + z[j+1] - a[j+1]*y[i]
+		}
+		z[n-2] = b[n-1]*x - a[n-1]*y[i]
+	}
+	return y, z
+}
+
+// LfilterZi returns the initial delay values of FilterWithState that correspond to the steady state of
+// the step response, so that filtering a signal scaled by its first sample starts without a transient.
+func LfilterZi(b []float64, a []float64) []float64 {
+	b, a = normalizeFilter("lfilter_zi", b, a)
+	n := len(a)
+	if Sum(a) == 0 {
+		panic("lfilter_zi: filter has a pole at z = 1 and no steady state")
+	}
+	steady := Sum(b) / Sum(a)
+
+	// in steady state each delay holds the sum of the remaining taps: zi[i] = sum_{k>i} b[k] - a[k]*y
+	zi := make([]float64, n-1)
+	var acc float64
+	for i := n - 1; i > 0; i-- {
+		acc += b[i] - a[i]*steady
+		zi[i-1] = acc
+	}
+	return zi
+}
+
+// FiltFiltOptions configures FiltFiltWith. The zero value selects the scipy defaults.
+type FiltFiltOptions struct {
+	// PadType is the extension applied at both ends of the signal: "odd" (default), "even", "constant" or
+	// "none". "none" filters the signal without extending it, like scipy's padtype=None or padlen=0, which
+	// PadLen cannot select since zero means the default.
+	PadType string
+	// PadLen is the number of samples added at each end, defaulting to 3 * max(len(a), len(b)).
+	PadLen int
+	// Method is "pad" (default) to extend the signal or "gust" to use Gustafsson's method for the initial conditions.
+	Method string
+	// IRLen is the length of the impulse response used by Gustafsson's method, 0 uses the whole signal.
+	IRLen int
+}
+
+// FiltFilt applies the filter defined by b and a forward and backward to data, giving a zero-phase
+// output with the default odd padding.
+func FiltFilt(b []float64, a []float64, data []float64) []float64 {
+	return FiltFiltWith(b, a, data, FiltFiltOptions{})
+}
+
+// FiltFiltWith applies the filter defined by b and a forward and backward to data using the padding
+// or initial condition strategy selected by opts.
+func FiltFiltWith(b []float64, a []float64, data []float64, opts FiltFiltOptions) []float64 {
+	if opts.Method != "" && opts.Method != "pad" && opts.Method != "gust" {
+		panic(fmt.Sprintf("filtfilt: unknown method %q", opts.Method))
+	}
+	if len(data) == 0 {
+		return []float64{}
+	}
+	if opts.Method == "gust" {
+		return filtFiltGust(b, a, data, opts.IRLen)
+	}
+
+	edge := opts.PadLen
+	switch {
+	case opts.PadType == "none":
+		edge = 0
+	case edge == 0:
+		edge = 3 * int(math.Max(float64(len(a)), float64(len(b))))
+	}
+	ext := padSignal("filtfilt", data, opts.PadType, edge)
+
+	zi := LfilterZi(b, a)
+	y, _ := FilterWithState(b, a, ext, MultiplyBy(zi, ext[0]))
+	y, _ = FilterWithState(b, a, Flipud(y), MultiplyBy(zi, y[len(y)-1]))
+	y = Flipud(y)
+	return y[edge : len(y)-edge]
+}
+
+// padSignal extends data by edge samples at both ends with an odd, even or constant extension
+func padSignal(name string, data []float64, padType string, edge int) []float64 {
+	switch padType {
+	case "none":
+		return append([]float64{}, data...)
+	case "", "odd", "even", "constant":
+	default:
+		panic(fmt.Sprintf("%s: unknown padtype %q", name, padType))
+	}
+	if edge < 0 {
+		panic(fmt.Sprintf("%s: padlen must be nonnegative", name))
+	}
+	if len(data) <= edge {
+		panic(fmt.Sprintf("%s: the length of the input vector must be greater than padlen, which is %d", name, edge))
+	}
+
+	n := len(data)
+	ext := make([]float64, n+2*edge)
+	copy(ext[edge:], data)
+	for i := 1; i <= edge; i++ {
+		switch padType {
+		case "", "odd":
+			ext[edge-i] = 2*data[0] - data[i]
+			ext[edge+n-1+i] = 2*data[n-1] - data[n-1-i]
+		case "even":
+			ext[edge-i] = data[i]
+			ext[edge+n-1+i] = data[n-1-i]
+		case "constant":
+			ext[edge-i] = data[0]
+			ext[edge+n-1+i] = data[n-1]
+		}
+	}
+	return ext
+}
+
+// filtFiltGust implements the forward-backward filter with the initial conditions chosen by
+// Gustafsson's method, so that the forward-backward and backward-forward outputs match
+func filtFiltGust(b []float64, a []float64, data []float64, irlen int) []float64 {
+	b, a = normalizeFilter("filtfilt", b, a)
+	order := len(a) - 1
+	if order == 0 {
+		// the filter is just a scalar multiplication with no state
+		return MultiplyBy(data, b[0]*b[0])
+	}
+
+	n := len(data)
+	m := n
+	if irlen > 0 && n > 2*irlen {
+		m = irlen
+	}
+
+	// obs is the observability matrix, propagating an initial state to the output with zero input
+	obs := Zeros(m, order)
+	zi := make([]float64, order)
+	zi[0] = 1
+	impulse, _ := FilterWithState(b, a, make([]float64, m), zi)
+	for k := 0; k < order; k++ {
+		for i := k; i < m; i++ {
+			obs[i][k] = impulse[i-k]
+		}
+	}
+
+	// s applies the filter to the reversed propagated initial conditions
+	s := Zeros(m, order)
+	for k := 0; k < order; k++ {
+		column := Filter(b, a, Flipud(GetColumn(obs, k)))
+		for i := range column {
+			s[i][k] = column[i]
+		}
+	}
+
+	// mat is [(S^R - O), (O^R - S)], block diagonal when only the ends of the signal are considered
+	var mat [][]float64
+	if m == n {
+		for i := 0; i < m; i++ {
+			var row []float64
+			for k := 0; k < order; k++ {
+				row = append(row, s[m-1-i][k]-obs[i][k])
+			}
+			for k := 0; k < order; k++ {
+				row = append(row, obs[m-1-i][k]-s[i][k])
+			}
+			mat = append(mat, row)
+		}
+	} else {
+		mat = Zeros(2*m, 2*order)
+		for i := 0; i < m; i++ {
+			for k := 0; k < order; k++ {
+				mat[i][k] = s[m-1-i][k] - obs[i][k]
+				mat[m+i][order+k] = obs[m-1-i][k] - s[i][k]
+			}
+		}
+	}
+
+	// naive forward-backward and backward-forward filters with zero initial conditions
+	yF := Filter(b, a, data)
+	yFB := Flipud(Filter(b, a, Flipud(yF)))
+	yB := Flipud(Filter(b, a, Flipud(data)))
+	yBF := Filter(b, a, yB)
+
+	var delta []float64
+	if m == n {
+		for i := range yBF {
+			delta = append(delta, yBF[i]-yFB[i])
+		}
+	} else {
+		for i := 0; i < m; i++ {
+			delta = append(delta, yBF[i]-yFB[i])
+		}
+		for i := n - m; i < n; i++ {
+			delta = append(delta, yBF[i]-yFB[i])
+		}
+	}
+	icOpt := leastSquares(mat, delta)
+
+	// the optimal output is Y_fb + [S^R, O^R] [x_0; x_N]
+	yOpt := yFB
+	for i := 0; i < m; i++ {
+		var start, end float64
+		for k := 0; k < order; k++ {
+			start += s[m-1-i][k] * icOpt[k]
+			end += obs[m-1-i][k] * icOpt[order+k]
+		}
+		if m == n {
+			yOpt[i] += start + end
+		} else {
+			yOpt[i] += start
+			yOpt[n-m+i] += end
+		}
+	}
+	return yOpt
+}
+
+// leastSquares solves min ||mat x - rhs|| for a full column rank matrix with Householder QR
+func leastSquares(mat [][]float64, rhs []float64) []float64 {
+	rows, cols := len(mat), len(mat[0])
+	r := make([][]float64, rows)
+	for i := range mat {
+		r[i] = append([]float64{}, mat[i]...)
+	}
+	y := append([]float64{}, rhs...)
+
+	for k := 0; k < cols && k < rows; k++ {
+		var norm float64
+		for i := k; i < rows; i++ {
+			norm = math.Hypot(norm, r[i][k])
+		}
+		if norm == 0 {
+			continue
+		}
+		if r[k][k] > 0 {
+			norm = -norm
+		}
+		// v = x - norm e_k, stored in place below the diagonal
+		v := make([]float64, rows-k)
+		for i := k; i < rows; i++ {
+			v[i-k] = r[i][k]
+		}
+		v[0] -= norm
+		vNorm2 := Dot(v, v)
+		if vNorm2 == 0 {
+			continue
+		}
+		for j := k; j < cols; j++ {
+			var proj float64
+			for i := k; i < rows; i++ {
+				proj += v[i-k] * r[i][j]
+			}
+			proj = 2 * proj / vNorm2
+			for i := k; i < rows; i++ {
+				r[i][j] -= proj * v[i-k]
+			}
+		}
+		var proj float64
+		for i := k; i < rows; i++ {
+			proj += v[i-k] * y[i]
+		}
+		proj = 2 * proj / vNorm2
+		for i := k; i < rows; i++ {
+			y[i] -= proj * v[i-k]
+		}
+	}
+
+	// back substitution, leaving the components of rank deficient columns at zero
+	x := make([]float64, cols)
+	for k := int(math.Min(float64(cols), float64(rows))) - 1; k >= 0; k-- {
+		if math.Abs(r[k][k]) <= 1e-14*math.Abs(r[0][0]) {
+			continue
+		}
+		sum := y[k]
+		for j := k + 1; j < cols; j++ {
+			sum -= r[k][j] * x[j]
+		}
+		x[k] = sum / r[k][k]
+	}
+	return x
+}
+
+// normalizeFilter scales the filter coefficients so that a[0] is 1 and pads b and a to the same length
+func normalizeFilter(name string, b []float64, a []float64) ([]float64, []float64) {
+	for len(a) > 1 && a[0] == 0 {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0] == 0 {
+		panic(fmt.Sprintf("%s: first element of a must be nonzero", name))
+	}
+	if len(b) == 0 {
+		panic(fmt.Sprintf("%s: b must not be empty", name))
+	}
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	bNorm := make([]float64, n)
+	aNorm := make([]float64, n)
+	for i := range b {
+		bNorm[i] = b[i] / a[0]
+	}
+	for i := range a {
+		aNorm[i] = a[i] / a[0]
+	}
+	return bNorm, aNorm
+}
+
+// bandTypes maps the band type names accepted by scipy.signal to their canonical form
+var bandTypes = map[string]string{
+	"lowpass":  "lowpass",
+	"low":      "lowpass",
+	"lp":       "lowpass",
+	"l":        "lowpass",
+	"highpass": "highpass",
+	"high":     "highpass",
+	"hp":       "highpass",
+	"h":        "highpass",
+	"bandpass": "bandpass",
+	"band":     "bandpass",
+	"pass":     "bandpass",
+	"bp":       "bandpass",
+	"bandstop": "bandstop",
+	"stop":     "bandstop",
+	"bs":       "bandstop",
+}
+
+// IIRFilterOptions configures IIRFilter. The zero value designs a Butterworth filter returned as b and a.
+type IIRFilterOptions struct {
+	// FType is the filter design: "butter" (default), "cheby1", "cheby2" or "bessel".
+	FType string
+	// Rp is the peak-to-peak ripple in decibels in the passband of a Chebyshev type I filter.
+	Rp float64
+	// Rs is the minimum attenuation in decibels in the stopband of a Chebyshev type II filter.
+	Rs float64
+	// Norm is the critical frequency normalization of a Bessel filter, described with Bessel.
+	Norm string
+	// Output is the representation of the filter: "ba" (default), "zpk" or "sos".
+	Output string
+}
+
+// IIRDesign is a digital filter in the representation selected by IIRFilterOptions.Output: the
+// numerator B and denominator A, the zeros Z, poles P and gain K, or the second-order sections SOS.
+// The fields of the other representations are left empty.
+type IIRDesign struct {
+	B, A []float64
+	Z, P []complex128
+	K    float64
+	SOS  [][]float64
+}
+
+// IIRFilter designs an Nth-order digital filter of the design in opts, like scipy.signal.iirfilter. Wn
+// holds the critical frequencies normalized to the Nyquist frequency, one value for lowpass and
+// highpass filters and two values for bandpass and bandstop filters. The SOS output keeps high-order
+// filters with narrow bands stable.
+func IIRFilter(Wn []float64, filterOrder int, filterType string, opts IIRFilterOptions) IIRDesign {
+	if opts.Output != "" && opts.Output != "ba" && opts.Output != "zpk" && opts.Output != "sos" {
+		panic(fmt.Sprintf("iirfilter: unknown output %q", opts.Output))
+	}
+	var z, p []complex128
+	var k float64
+	switch opts.FType {
+	case "", "butter":
+		z, p, k = buttap(filterOrder)
+		z, p, k = iirFilter("butter", Wn, filterOrder, filterType, z, p, k)
+	case "cheby1":
+		if opts.Rp <= 0 {
+			panic("cheby1: passband ripple (rp) must be positive")
+		}
+		z, p, k = cheb1ap(filterOrder, opts.Rp)
+		z, p, k = iirFilter("cheby1", Wn, filterOrder, filterType, z, p, k)
+	case "cheby2":
+		if opts.Rs <= 0 {
+			panic("cheby2: stopband attenuation (rs) must be positive")
+		}
+		z, p, k = cheb2ap(filterOrder, opts.Rs)
+		z, p, k = iirFilter("cheby2", Wn, filterOrder, filterType, z, p, k)
+	case "bessel":
+		if filterOrder < 0 {
+			panic("bessel: filter order must be a nonnegative integer")
+		}
+		z, p, k = besselap(filterOrder, opts.Norm)
+		z, p, k = iirFilter("bessel", Wn, filterOrder, filterType, z, p, k)
+	default:
+		panic(fmt.Sprintf("iirfilter: unknown filter design %q", opts.FType))
+	}
+
+	switch opts.Output {
+	case "zpk":
+		return IIRDesign{Z: z, P: p, K: k}
+	case "sos":
+		return IIRDesign{SOS: Zpk2Sos(z, p, k)}
+	}
+	b, a := zpk2tf(z, p, k)
+	return IIRDesign{B: b, A: a}
+}
+
+// Butterworth designs an Nth-order digital Butterworth filter and returns the numerator (b) and
+// denominator (a) coefficients. Wn holds the critical frequencies normalized to the Nyquist frequency,
+// one value for lowpass and highpass filters and two values for bandpass and bandstop filters.
+func Butterworth(Wn []float64, filterOrder int, filterType string) ([]float64, []float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{})
+	return design.B, design.A
+}
+
+// ButterworthZPK designs an Nth-order digital Butterworth filter and returns its zeros, poles and gain.
+func ButterworthZPK(Wn []float64, filterOrder int, filterType string) ([]complex128, []complex128, float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{Output: "zpk"})
+	return design.Z, design.P, design.K
+}
+
+// Cheby1 designs an Nth-order digital Chebyshev type I filter with rp decibels of peak-to-peak ripple
+// in the passband and returns the numerator (b) and denominator (a) coefficients.
+func Cheby1(Wn []float64, filterOrder int, rp float64, filterType string) ([]float64, []float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby1", Rp: rp})
+	return design.B, design.A
+}
+
+// Cheby1ZPK designs an Nth-order digital Chebyshev type I filter and returns its zeros, poles and gain.
+func Cheby1ZPK(Wn []float64, filterOrder int, rp float64, filterType string) ([]complex128, []complex128, float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby1", Rp: rp, Output: "zpk"})
+	return design.Z, design.P, design.K
+}
+
+// Cheby2 designs an Nth-order digital Chebyshev type II filter with a minimum attenuation of rs decibels
+// in the stopband and returns the numerator (b) and denominator (a) coefficients.
+func Cheby2(Wn []float64, filterOrder int, rs float64, filterType string) ([]float64, []float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby2", Rs: rs})
+	return design.B, design.A
+}
+
+// Cheby2ZPK designs an Nth-order digital Chebyshev type II filter and returns its zeros, poles and gain.
+func Cheby2ZPK(Wn []float64, filterOrder int, rs float64, filterType string) ([]complex128, []complex128, float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby2", Rs: rs, Output: "zpk"})
+	return design.Z, design.P, design.K
+}
+
+// Bessel designs an Nth-order digital Bessel/Thomson filter and returns the numerator (b) and
+// denominator (a) coefficients. norm selects the critical frequency normalization: "phase" (default)
+// places half of the maximum phase shift at Wn, "delay" normalizes the group delay in the passband to
+// 1/Wn and "mag" puts the -3 dB point at Wn.
+func Bessel(Wn []float64, filterOrder int, filterType string, norm string) ([]float64, []float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "bessel", Norm: norm})
+	return design.B, design.A
+}
+
+// BesselZPK designs an Nth-order digital Bessel/Thomson filter and returns its zeros, poles and gain.
+func BesselZPK(Wn []float64, filterOrder int, filterType string, norm string) ([]complex128, []complex128, float64) {
+	design := IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "bessel", Norm: norm, Output: "zpk"})
+	return design.Z, design.P, design.K
+}
+
+// iirFilter transforms an analog lowpass prototype to the requested digital band type
+func iirFilter(name string, Wn []float64, order int, filterType string, z, p []complex128, k float64) ([]complex128, []complex128, float64) {
+	if order < 0 {
+		panic(fmt.Sprintf("%s: filter order must be a nonnegative integer", name))
+	}
+	btype, ok := bandTypes[filterType]
+	if !ok {
+		panic(fmt.Sprintf("%s: unknown filter type %q", name, filterType))
+	}
+	for _, w := range Wn {
+		if w <= 0 || w >= 1 {
+			panic(fmt.Sprintf("%s: critical frequencies must be 0 < Wn < 1", name))
+		}
+	}
+
+	// pre-warp the critical frequencies for the bilinear transform with fs = 2
+	fs := 2.0
+	var warped []float64
+	for _, w := range Wn {
+		warped = append(warped, 2*fs*math.Tan(math.Pi*w/fs))
+	}
+
+	switch btype {
+	case "lowpass", "highpass":
+		if len(warped) != 1 {
+			panic(fmt.Sprintf("%s: must specify a single critical frequency for %s filters", name, btype))
+		}
+		if btype == "lowpass" {
+			z, p, k = lp2lpZpk(z, p, k, warped[0])
+		} else {
+			z, p, k = lp2hpZpk(z, p, k, warped[0])
+		}
+	default:
+		if len(warped) != 2 {
+			panic(fmt.Sprintf("%s: Wn must specify start and stop frequencies for %s filters", name, btype))
+		}
+		if warped[0] >= warped[1] {
+			panic(fmt.Sprintf("%s: Wn[0] must be less than Wn[1]", name))
+		}
+		bw := warped[1] - warped[0]
+		wo := math.Sqrt(warped[0] * warped[1])
+		if btype == "bandpass" {
+			z, p, k = lp2bpZpk(z, p, k, wo, bw)
+		} else {
+			z, p, k = lp2bsZpk(z, p, k, wo, bw)
+		}
+	}
+
+	return bilinearZpk(z, p, k, fs)
+}
+
+// buttap returns the zeros, poles and gain of an Nth-order analog Butterworth lowpass prototype
+func buttap(order int) ([]complex128, []complex128, float64) {
+	var p []complex128
+	for m := -order + 1; m < order; m += 2 {
+		p = append(p, -cmplx.Exp(complex(0, math.Pi*float64(m)/float64(2*order))))
+	}
+	return nil, p, 1
+}
+
+// cheb1ap returns the zeros, poles and gain of an Nth-order analog Chebyshev type I lowpass prototype
+// with rp decibels of ripple in the passband
+func cheb1ap(order int, rp float64) ([]complex128, []complex128, float64) {
+	if order == 0 {
+		// a zeroth-order filter is a constant gain at the bottom of the ripple
+		return nil, nil, math.Pow(10, -rp/20)
+	}
+	eps := math.Sqrt(math.Pow(10, 0.1*rp) - 1)
+	mu := math.Asinh(1/eps) / float64(order)
+
+	var p []complex128
+	for m := -order + 1; m < order; m += 2 {
+		theta := math.Pi * float64(m) / float64(2*order)
+		p = append(p, -cmplx.Sinh(complex(mu, theta)))
+	}
+	k := real(prodComplex(negateComplex(p)))
+	if order%2 == 0 {
+		k = k / math.Sqrt(1+eps*eps)
+	}
+	return nil, p, k
+}
+
+// cheb2ap returns the zeros, poles and gain of an Nth-order analog Chebyshev type II lowpass prototype
+// with rs decibels of attenuation in the stopband
+func cheb2ap(order int, rs float64) ([]complex128, []complex128, float64) {
+	if order == 0 {
+		return nil, nil, 1
+	}
+	de := 1 / math.Sqrt(math.Pow(10, 0.1*rs)-1)
+	mu := math.Asinh(1/de) / float64(order)
+
+	// odd orders skip the zero at infinity
+	var z []complex128
+	for m := -order + 1; m < order; m += 2 {
+		if m == 0 {
+			continue
+		}
+		z = append(z, -cmplx.Conj(complex(0, 1)/complex(math.Sin(float64(m)*math.Pi/float64(2*order)), 0)))
+	}
+
+	var p []complex128
+	for m := -order + 1; m < order; m += 2 {
+		v := -cmplx.Exp(complex(0, math.Pi*float64(m)/float64(2*order)))
+		p = append(p, 1/complex(math.Sinh(mu)*real(v), math.Cosh(mu)*imag(v)))
+	}
+	k := real(prodComplex(negateComplex(p)) / prodComplex(negateComplex(z)))
+	return z, p, k
+}
+
+// besselap returns the zeros, poles and gain of an Nth-order analog Bessel lowpass prototype
+func besselap(order int, norm string) ([]complex128, []complex128, float64) {
+	if norm == "" {
+		norm = "phase"
+	}
+	if norm != "phase" && norm != "delay" && norm != "mag" {
+		panic(fmt.Sprintf("bessel: unknown normalization %q", norm))
+	}
+	if order == 0 {
+		return nil, nil, 1
+	}
+
+	// the poles of the delay normalized filter are the roots of the reverse Bessel polynomial
+	// theta_N(s) = sum a_i s^i with a_i = (2N-i)! / (2^(N-i) i! (N-i)!)
+	coeffs := make([]float64, order+1)
+	coeffs[0] = 1
+	for i := order - 1; i >= 0; i-- {
+		coeffs[order-i] = coeffs[order-i-1] * float64((2*order-i)*(i+1)) / float64(2*(order-i))
+	}
+	p := besselZeros(order, coeffs)
+	aLast := coeffs[order]
+
+	var k float64
+	switch norm {
+	case "delay":
+		k = aLast
+	case "mag":
+		normFactor := besselNormFactor(p, aLast)
+		for i := range p {
+			p[i] /= complex(normFactor, 0)
+		}
+		k = math.Pow(normFactor, -float64(order)) * aLast
+	default:
+		// the asymptotes of the phase normalized filter are the same as a Butterworth filter
+		scale := math.Pow(10, -math.Log10(aLast)/float64(order))
+		for i := range p {
+			p[i] *= complex(scale, 0)
+		}
+		k = 1
+	}
+	return nil, p, k
+}
+
+// besselNormFactor returns the frequency at which the analog filter with poles p and gain k has a magnitude of -3 dB
+func besselNormFactor(p []complex128, k float64) float64 {
+	gain := func(w float64) float64 {
+		den := complex(1, 0)
+		for _, v := range p {
+			den *= complex(0, w) - v
+		}
+		return k / cmplx.Abs(den)
+	}
+
+	// the magnitude decreases monotonically, so bracket the cutoff and bisect
+	low, high := 0.0, 1.0
+	for gain(high) > 1/math.Sqrt2 {
+		low, high = high, 2*high
+	}
+	for i := 0; i < 200 && high-low > 1e-15*high; i++ {
+		mid := (low + high) / 2
+		if gain(mid) > 1/math.Sqrt2 {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// besselZeros returns the roots of the reverse Bessel polynomial of the given order, whose coefficients
+// given highest power first are coeffs, found with the Aberth-Ehrlich simultaneous iteration. The roots
+// close to the real axis are badly conditioned at high orders, so the polynomial is evaluated with
+// besselPolyEval rather than with Horner's rule on the rounded coefficients.
+func besselZeros(order int, coeffs []float64) []complex128 {
+	// start from points spread on a circle with the geometric mean radius of the roots
+	radius := math.Pow(math.Abs(coeffs[order]/coeffs[0]), 1/float64(order))
+	if radius == 0 {
+		radius = 1
+	}
+	roots := make([]complex128, order)
+	for i := range roots {
+		angle := 2*math.Pi*float64(i)/float64(order) + 0.4
+		roots[i] = cmplx.Rect(radius, angle)
+	}
+
+	for iter := 0; iter < 500; iter++ {
+		converged := true
+		for i := range roots {
+			value, deriv := besselPolyEval(order, roots[i])
+			if value == 0 {
+				continue
+			}
+			ratio := value / deriv
+			var repulsion complex128
+			for j := range roots {
+				if j != i {
+					repulsion += 1 / (roots[i] - roots[j])
+				}
+			}
+			step := ratio / (1 - ratio*repulsion)
+			roots[i] -= step
+			if cmplx.Abs(step) > 1e-15*math.Max(cmplx.Abs(roots[i]), 1) {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+	}
+
+	// snap roots that are numerically real and make complex roots exact conjugate pairs
+	for i := range roots {
+		if math.Abs(imag(roots[i])) <= 1e-12*cmplx.Abs(roots[i]) {
+			roots[i] = complex(real(roots[i]), 0)
+		}
+	}
+	paired := make([]bool, order)
+	for i := range roots {
+		if imag(roots[i]) <= 0 {
+			continue
+		}
+		best := -1
+		for j := range roots {
+			if paired[j] || imag(roots[j]) >= 0 {
+				continue
+			}
+			if best < 0 || cmplx.Abs(roots[j]-cmplx.Conj(roots[i])) < cmplx.Abs(roots[best]-cmplx.Conj(roots[i])) {
+				best = j
+			}
+		}
+		if best >= 0 {
+			paired[best] = true
+			mean := (roots[i] + cmplx.Conj(roots[best])) / 2
+			roots[i] = mean
+			roots[best] = cmplx.Conj(mean)
+		}
+	}
+	return roots
+}
+
+// besselPolyEval returns the reverse Bessel polynomial of the given order and its derivative at s, using
+// the recurrence theta_n(s) = (2n-1) theta_(n-1)(s) + s^2 theta_(n-2)(s) in extended precision. Its
+// coefficients are exact integers, so the value keeps its relative accuracy next to a root.
+func besselPolyEval(order int, s complex128) (complex128, complex128) {
+	const prec = 192
+	newFloat := func(v float64) *big.Float { return new(big.Float).SetPrec(prec).SetFloat64(v) }
+	// mul returns x*y for complex numbers stored as pairs of real and imaginary parts
+	mul := func(x, y [2]*big.Float) [2]*big.Float {
+		re := new(big.Float).SetPrec(prec).Mul(x[0], y[0])
+		re.Sub(re, new(big.Float).SetPrec(prec).Mul(x[1], y[1]))
+		im := new(big.Float).SetPrec(prec).Mul(x[0], y[1])
+		im.Add(im, new(big.Float).SetPrec(prec).Mul(x[1], y[0]))
+		return [2]*big.Float{re, im}
+	}
+	// axpy returns a*x + y for a real integer a
+	axpy := func(a float64, x, y [2]*big.Float) [2]*big.Float {
+		re := new(big.Float).SetPrec(prec).Mul(newFloat(a), x[0])
+		im := new(big.Float).SetPrec(prec).Mul(newFloat(a), x[1])
+		return [2]*big.Float{re.Add(re, y[0]), im.Add(im, y[1])}
+	}
+	toComplex := func(x [2]*big.Float) complex128 {
+		re, _ := x[0].Float64()
+		im, _ := x[1].Float64()
+		return complex(re, im)
+	}
+
+	z := [2]*big.Float{newFloat(real(s)), newFloat(imag(s))}
+	zz := mul(z, z)
+	twoZ := axpy(2, z, [2]*big.Float{newFloat(0), newFloat(0)})
+	prev, value := [2]*big.Float{newFloat(1), newFloat(0)}, axpy(1, z, [2]*big.Float{newFloat(1), newFloat(0)})
+	prevDeriv, deriv := [2]*big.Float{newFloat(0), newFloat(0)}, [2]*big.Float{newFloat(1), newFloat(0)}
+	for n := 2; n <= order; n++ {
+		nextDeriv := axpy(float64(2*n-1), deriv, axpy(1, mul(twoZ, prev), mul(zz, prevDeriv)))
+		next := axpy(float64(2*n-1), value, mul(zz, prev))
+		prev, value, prevDeriv, deriv = value, next, deriv, nextDeriv
+	}
+	return toComplex(value), toComplex(deriv)
+}
+
+// lp2lpZpk transforms a lowpass prototype to a lowpass filter with cutoff frequency wo
+func lp2lpZpk(z, p []complex128, k float64, wo float64) ([]complex128, []complex128, float64) {
+	degree := len(p) - len(z)
+	var zLp, pLp []complex128
+	for _, v := range z {
+		zLp = append(zLp, v*complex(wo, 0))
+	}
+	for _, v := range p {
+		pLp = append(pLp, v*complex(wo, 0))
+	}
+	return zLp, pLp, k * math.Pow(wo, float64(degree))
+}
+
+// lp2hpZpk transforms a lowpass prototype to a highpass filter with cutoff frequency wo
+func lp2hpZpk(z, p []complex128, k float64, wo float64) ([]complex128, []complex128, float64) {
+	degree := len(p) - len(z)
+	var zHp, pHp []complex128
+	for _, v := range z {
+		zHp = append(zHp, complex(wo, 0)/v)
+	}
+	for _, v := range p {
+		pHp = append(pHp, complex(wo, 0)/v)
+	}
+	for i := 0; i < degree; i++ {
+		zHp = append(zHp, 0)
+	}
+	return zHp, pHp, k * real(prodComplex(negateComplex(z))/prodComplex(negateComplex(p)))
+}
+
+// lp2bpZpk transforms a lowpass prototype to a bandpass filter with center frequency wo and bandwidth bw
+func lp2bpZpk(z, p []complex128, k float64, wo, bw float64) ([]complex128, []complex128, float64) {
+	degree := len(p) - len(z)
+	half := complex(bw/2, 0)
+	wo2 := complex(wo*wo, 0)
+	var zBp, pBp []complex128
+	for _, v := range z {
+		zBp = append(zBp, v*half+cmplx.Sqrt(v*half*v*half-wo2))
+	}
+	for _, v := range z {
+		zBp = append(zBp, v*half-cmplx.Sqrt(v*half*v*half-wo2))
+	}
+	for _, v := range p {
+		pBp = append(pBp, v*half+cmplx.Sqrt(v*half*v*half-wo2))
+	}
+	for _, v := range p {
+		pBp = append(pBp, v*half-cmplx.Sqrt(v*half*v*half-wo2))
+	}
+	for i := 0; i < degree; i++ {
+		zBp = append(zBp, 0)
+	}
+	return zBp, pBp, k * math.Pow(bw, float64(degree))
+}
+
+// lp2bsZpk transforms a lowpass prototype to a bandstop filter with center frequency wo and bandwidth bw
+func lp2bsZpk(z, p []complex128, k float64, wo, bw float64) ([]complex128, []complex128, float64) {
+	degree := len(p) - len(z)
+	half := complex(bw/2, 0)
+	wo2 := complex(wo*wo, 0)
+	var zBs, pBs []complex128
+	for _, v := range z {
+		h := half / v
+		zBs = append(zBs, h+cmplx.Sqrt(h*h-wo2))
+	}
+	for _, v := range z {
+		h := half / v
+		zBs = append(zBs, h-cmplx.Sqrt(h*h-wo2))
+	}
+	for _, v := range p {
+		h := half / v
+		pBs = append(pBs, h+cmplx.Sqrt(h*h-wo2))
+	}
+	for _, v := range p {
+		h := half / v
+		pBs = append(pBs, h-cmplx.Sqrt(h*h-wo2))
+	}
+	for i := 0; i < degree; i++ {
+		zBs = append(zBs, complex(0, wo))
+	}
+	for i := 0; i < degree; i++ {
+		zBs = append(zBs, complex(0, -wo))
+	}
+	return zBs, pBs, k * real(prodComplex(negateComplex(z))/prodComplex(negateComplex(p)))
+}
+
+// bilinearZpk maps an analog filter to the z-plane using the bilinear transform
+func bilinearZpk(z, p []complex128, k float64, fs float64) ([]complex128, []complex128, float64) {
+	degree := len(p) - len(z)
+	fs2 := complex(2*fs, 0)
+	var zZ, pZ, zDiff, pDiff []complex128
+	for _, v := range z {
+		zZ = append(zZ, (fs2+v)/(fs2-v))
+		zDiff = append(zDiff, fs2-v)
+	}
+	for _, v := range p {
+		pZ = append(pZ, (fs2+v)/(fs2-v))
+		pDiff = append(pDiff, fs2-v)
+	}
+	for i := 0; i < degree; i++ {
+		zZ = append(zZ, -1)
+	}
+	return zZ, pZ, k * real(prodComplex(zDiff)/prodComplex(pDiff))
+}
+
+// zpk2tf returns the polynomial transfer function coefficients of a filter given by its zeros, poles and gain
+func zpk2tf(z, p []complex128, k float64) ([]float64, []float64) {
+	b := Real(polyFromRoots(z))
+	for i := range b {
+		b[i] *= k
+	}
+	a := Real(polyFromRoots(p))
+	return b, a
+}
+
+// polyFromRoots returns the coefficients of the monic polynomial with the given roots, highest power first
+func polyFromRoots(roots []complex128) []complex128 {
+	coeffs := []complex128{1}
+	for _, r := range roots {
+		next := make([]complex128, len(coeffs)+1)
+		for i, c := range coeffs {
+			next[i] += c
+			next[i+1] -= c * r
+		}
+		coeffs = next
+	}
+	return coeffs
+}
+
+// prodComplex returns the product of a slice of complex numbers
+func prodComplex(array []complex128) complex128 {
+	result := complex(1, 0)
+	for _, v := range array {
+		result *= v
+	}
+	return result
+}
+
+// negateComplex returns the element-wise negation of a slice of complex numbers
+func negateComplex(array []complex128) []complex128 {
+	var result []complex128
+	for _, v := range array {
+		result = append(result, -v)
+	}
+	return result
+}
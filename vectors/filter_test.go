@@ -0,0 +1,390 @@
+package vectors
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// freqResponse evaluates the magnitude of the transfer function b/a at the normalized frequency w
+func freqResponse(b, a []float64, w float64) float64 {
+	z := cmplx.Exp(complex(0, -math.Pi*w))
+	var num, den complex128
+	for i, c := range b {
+		num += complex(c, 0) * cmplx.Pow(z, complex(float64(i), 0))
+	}
+	for i, c := range a {
+		den += complex(c, 0) * cmplx.Pow(z, complex(float64(i), 0))
+	}
+	return cmplx.Abs(num / den)
+}
+
+func TestFilter(t *testing.T) {
+	expected := []float64{1, 0.5, 0.25, 0.125, 0.0625}
+	output := Filter([]float64{1}, []float64{1, -0.5}, []float64{1, 0, 0, 0, 0})
+	if !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	expected = []float64{0.5, 1.5, 2.5, 3.5}
+	output = Filter([]float64{1, 1}, []float64{2}, []float64{1, 2, 3, 4})
+	if !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestFilterWithState(t *testing.T) {
+	b, a := Butterworth([]float64{0.25}, 4, "lowpass")
+	x := Apply(Arange(0.0, 50, 1), math.Sin)
+	expected := Filter(b, a, x)
+
+	first, zf := FilterWithState(b, a, x[:20], nil)
+	second, _ := FilterWithState(b, a, x[20:], zf)
+	output := append(first, second...)
+	if !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestLfilterZi(t *testing.T) {
+	b, a := Butterworth([]float64{0.25}, 5, "lowpass")
+	zi := LfilterZi(b, a)
+	output, _ := FilterWithState(b, a, Ones(10), zi)
+	if !AllClose(Ones(10), output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, Ones(10))
+	}
+
+	expected := []float64{0.5}
+	output = LfilterZi([]float64{1, 1}, []float64{2})
+	if !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	b, a := Butterworth([]float64{0.25}, 4, "lowpass")
+	x := Apply(Arange(0.0, 200, 1), func(v float64) float64 { return math.Sin(0.05 * math.Pi * v) })
+	gain := math.Pow(freqResponse(b, a, 0.05), 2)
+
+	for _, opts := range []FiltFiltOptions{{}, {PadType: "even"}, {PadType: "constant", PadLen: 30}, {Method: "gust"}, {Method: "gust", IRLen: 60}} {
+		output := FiltFiltWith(b, a, x, opts)
+		if len(output) != len(x) {
+			t.Fatalf("FiltFiltWith(%+v) returned %d samples, want %d", opts, len(output), len(x))
+		}
+		// zero phase: the passband sine comes out scaled by |H|^2 and without delay
+		if !AllClose(MultiplyBy(x[50:150], gain), output[50:150], 1e-6) {
+			t.Errorf("FiltFiltWith(%+v) shifted the passband signal", opts)
+		}
+		if opts.Method != "gust" || opts.IRLen != 0 {
+			continue
+		}
+		// Gustafsson's initial conditions make the output independent of the filtering direction
+		reversed := Flipud(FiltFiltWith(b, a, Flipud(x), opts))
+		if !AllClose(output, reversed, 1e-9) {
+			t.Errorf("FiltFiltWith(%+v) is not symmetric under time reversal", opts)
+		}
+	}
+
+	expected := Repeat(2.0, 40)
+	output := FiltFilt(b, a, expected)
+	if !AllClose(expected, output, 1e-9) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	// PadType none expresses scipy's padlen=0 and filters signals shorter than the default padding
+	short := x[:10]
+	zi := LfilterZi(b, a)
+	forward, _ := FilterWithState(b, a, short, MultiplyBy(zi, short[0]))
+	backward, _ := FilterWithState(b, a, Flipud(forward), MultiplyBy(zi, forward[len(forward)-1]))
+	if output := FiltFiltWith(b, a, short, FiltFiltOptions{PadType: "none"}); !AllClose(Flipud(backward), output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, Flipud(backward))
+	}
+	for _, opts := range []FiltFiltOptions{{}, {PadType: "none"}, {Method: "gust"}} {
+		if output := FiltFiltWith(b, a, nil, opts); output == nil || len(output) != 0 {
+			t.Errorf("FiltFiltWith(%+v) of an empty signal = %v, want []", opts, output)
+		}
+	}
+}
+
+func TestIIRFilter(t *testing.T) {
+	Wn := []float64{0.2, 0.4}
+	for _, opts := range []IIRFilterOptions{{}, {FType: "cheby1", Rp: 1}, {FType: "cheby2", Rs: 40}, {FType: "bessel", Norm: "mag"}} {
+		ba := IIRFilter(Wn, 4, "bandpass", opts)
+		opts.Output = "zpk"
+		zpk := IIRFilter(Wn, 4, "bandpass", opts)
+		opts.Output = "sos"
+		sos := IIRFilter(Wn, 4, "bandpass", opts)
+		if ba.SOS != nil || zpk.B != nil || sos.Z != nil || len(sos.SOS) != 4 {
+			t.Errorf("IIRFilter(%+v) set the fields of another output", opts)
+		}
+		// the three outputs describe the same filter
+		b, a := zpk2tf(zpk.Z, zpk.P, zpk.K)
+		sosB, sosA := Sos2Tf(sos.SOS)
+		if !AllClose(ba.B, b, 1e-12) || !AllClose(ba.A, a, 1e-12) || !AllClose(ba.B, sosB, 1e-10) || !AllClose(ba.A, sosA, 1e-10) {
+			t.Errorf("IIRFilter(%+v) outputs differ: %v, %v and %v, %v", opts, ba.B, ba.A, sosB, sosA)
+		}
+	}
+
+	for _, opts := range []IIRFilterOptions{{Output: "tf"}, {FType: "elliptic"}, {FType: "cheby1"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("IIRFilter(%+v) did not panic", opts)
+				}
+			}()
+			IIRFilter([]float64{0.3}, 2, "lowpass", opts)
+		}()
+	}
+}
+
+func TestButterworth(t *testing.T) {
+	// reference coefficients from scipy.signal.butter
+	references := []struct {
+		Wn    []float64
+		order int
+		btype string
+		b     []float64
+		a     []float64
+	}{
+		{[]float64{0.5}, 2, "lowpass", []float64{0.29289322, 0.58578644, 0.29289322}, []float64{1, 0, 0.17157288}},
+		{[]float64{0.5}, 3, "lowpass", []float64{0.16666667, 0.5, 0.5, 0.16666667}, []float64{1, 0, 0.33333333, 0}},
+		{[]float64{0.5}, 2, "highpass", []float64{0.29289322, -0.58578644, 0.29289322}, []float64{1, 0, 0.17157288}},
+		{[]float64{0.2}, 1, "low", []float64{0.24523728, 0.24523728}, []float64{1, -0.50952545}},
+		// the band and higher order cases were computed in double precision by a plain Python transcription
+		// of scipy's buttap, lp2lp_zpk, lp2hp_zpk, lp2bp_zpk, lp2bs_zpk, bilinear_zpk and zpk2tf, as scipy
+		// itself was not available
+		{[]float64{0.1, 0.3}, 4, "bandpass",
+			[]float64{0.0048243433577162325, 0, -0.01929737343086493, 0, 0.028946060146297393, 0, -0.01929737343086493, 0, 0.0048243433577162325},
+			[]float64{1, -5.418231388368233, 13.52935869832969, -20.319265119781697, 20.07119886116117, -13.34437166183811, 5.832106770220362, -1.534730046657901, 0.18737949236818485}},
+		{[]float64{0.2, 0.4}, 2, "bandstop",
+			[]float64{0.6389455251590221, -1.579560206031707, 2.254112944922426, -1.579560206031707, 0.6389455251590221},
+			[]float64{1, -1.942468776547884, 2.119202397144283, -1.216651635515531, 0.41280159809618855}},
+		{[]float64{0.3}, 6, "lowpass",
+			[]float64{0.002585064184237276, 0.015510385105423654, 0.03877596276355914, 0.05170128368474552, 0.03877596276355914, 0.015510385105423654, 0.002585064184237276},
+			[]float64{1, -2.379721044554775, 2.910406567864687, -2.055131436773097, 0.8779238976340886, -0.2098654503596896, 0.02183157397997184}},
+		{[]float64{0.25}, 8, "highpass",
+			[]float64{0.12452952906897828, -0.9962362325518263, 3.486826813931392, -6.973653627862784, 8.71706703482848, -6.973653627862784, 3.486826813931392, -0.9962362325518263, 0.12452952906897828},
+			[]float64{1, -3.983784273174194, 7.536234110120898, -8.599815064801401, 6.400154060347638, -3.156025260730566, 1.001696579551284, -0.18634247767748532, 0.015507615254986886}},
+	}
+	for _, ref := range references {
+		b, a := Butterworth(ref.Wn, ref.order, ref.btype)
+		if len(b) != len(ref.b) || len(a) != len(ref.a) || !AllClose(b, ref.b, 1e-8) || !AllClose(a, ref.a, 1e-8) {
+			t.Errorf("Butterworth(%v,%d,%s) = %v, %v, want %v, %v", ref.Wn, ref.order, ref.btype, b, a, ref.b, ref.a)
+		}
+	}
+
+	// the magnitude response is -3 dB at every critical frequency
+	cases := []struct {
+		Wn     []float64
+		btype  string
+		passAt float64
+	}{
+		{[]float64{0.1}, "lowpass", 0},
+		{[]float64{0.3}, "highpass", 1},
+		{[]float64{0.2, 0.4}, "bandpass", 2 / math.Pi * math.Atan(math.Sqrt(math.Tan(0.1*math.Pi)*math.Tan(0.2*math.Pi)))},
+		{[]float64{0.2, 0.4}, "bandstop", 0},
+	}
+	for _, c := range cases {
+		for _, order := range []int{1, 4, 7} {
+			b, a := Butterworth(c.Wn, order, c.btype)
+			for _, w := range c.Wn {
+				if mag := freqResponse(b, a, w); math.Abs(mag-1/math.Sqrt2) > 1e-9 {
+					t.Errorf("Butterworth(%v,%d,%s) gain at %v = %v, want %v", c.Wn, order, c.btype, w, mag, 1/math.Sqrt2)
+				}
+			}
+			if mag := freqResponse(b, a, c.passAt); math.Abs(mag-1) > 1e-9 {
+				t.Errorf("Butterworth(%v,%d,%s) passband gain = %v, want 1", c.Wn, order, c.btype, mag)
+			}
+		}
+	}
+}
+
+func TestCheby1(t *testing.T) {
+	rp := 1.0
+	for _, order := range []int{1, 2, 5, 6} {
+		b, a := Cheby1([]float64{0.3}, order, rp, "lowpass")
+		if mag := freqResponse(b, a, 0.3); math.Abs(mag-math.Pow(10, -rp/20)) > 1e-9 {
+			t.Errorf("Cheby1 order %d gain at Wn = %v, want %v", order, mag, math.Pow(10, -rp/20))
+		}
+		// odd orders pass DC with unit gain, even orders sit at the bottom of the ripple
+		dc := 1.0
+		if order%2 == 0 {
+			dc = math.Pow(10, -rp/20)
+		}
+		if mag := freqResponse(b, a, 0); math.Abs(mag-dc) > 1e-9 {
+			t.Errorf("Cheby1 order %d DC gain = %v, want %v", order, mag, dc)
+		}
+	}
+
+	for _, btype := range []string{"highpass", "bandpass", "bandstop"} {
+		Wn := []float64{0.4}
+		if btype != "highpass" {
+			Wn = []float64{0.2, 0.5}
+		}
+		b, a := Cheby1(Wn, 3, rp, btype)
+		for _, w := range Wn {
+			if mag := freqResponse(b, a, w); math.Abs(mag-math.Pow(10, -rp/20)) > 1e-9 {
+				t.Errorf("Cheby1(%v,3,%v,%s) gain at %v = %v", Wn, rp, btype, w, mag)
+			}
+		}
+	}
+
+	z, p, k := Cheby1ZPK([]float64{0.3}, 4, rp, "lowpass")
+	if len(z) != 4 || len(p) != 4 || k <= 0 {
+		t.Errorf("Cheby1ZPK returned %d zeros, %d poles and gain %v", len(z), len(p), k)
+	}
+	for _, pole := range p {
+		if cmplx.Abs(pole) >= 1 {
+			t.Errorf("Cheby1ZPK pole %v is outside the unit circle", pole)
+		}
+	}
+}
+
+func TestCheby2(t *testing.T) {
+	rs := 40.0
+	for _, order := range []int{1, 2, 5, 6} {
+		b, a := Cheby2([]float64{0.3}, order, rs, "lowpass")
+		if mag := freqResponse(b, a, 0.3); math.Abs(mag-math.Pow(10, -rs/20)) > 1e-9 {
+			t.Errorf("Cheby2 order %d gain at Wn = %v, want %v", order, mag, math.Pow(10, -rs/20))
+		}
+		if mag := freqResponse(b, a, 0); math.Abs(mag-1) > 1e-9 {
+			t.Errorf("Cheby2 order %d DC gain = %v, want 1", order, mag)
+		}
+	}
+
+	for _, btype := range []string{"highpass", "bandpass", "bandstop"} {
+		Wn := []float64{0.4}
+		if btype != "highpass" {
+			Wn = []float64{0.2, 0.5}
+		}
+		b, a := Cheby2(Wn, 4, rs, btype)
+		for _, w := range Wn {
+			if mag := freqResponse(b, a, w); math.Abs(mag-math.Pow(10, -rs/20)) > 1e-9 {
+				t.Errorf("Cheby2(%v,4,%v,%s) gain at %v = %v", Wn, rs, btype, w, mag)
+			}
+		}
+	}
+
+	z, _, _ := Cheby2ZPK([]float64{0.3}, 4, rs, "lowpass")
+	for _, zero := range z {
+		if math.Abs(cmplx.Abs(zero)-1) > 1e-12 {
+			t.Errorf("Cheby2ZPK zero %v is not on the unit circle", zero)
+		}
+	}
+}
+
+func TestBessel(t *testing.T) {
+	// reference coefficients of scipy.signal.bessel(order, 0.2, norm=norm), computed independently of
+	// this package by following besselap, lp2lp_zpk, bilinear_zpk and zpk2tf
+	references := []struct {
+		order int
+		norm  string
+		b     []float64
+		a     []float64
+	}{
+		{3, "phase", []float64{0.016450876442181968, 0.049352629326545903, 0.049352629326545903, 0.016450876442181968},
+			[]float64{1, -1.6436246357718476, 0.98412289416985743, -0.20889124686055399}},
+		{4, "phase", []float64{0.0042874202929168506, 0.017149681171667402, 0.025724521757501104, 0.017149681171667402, 0.0042874202929168506},
+			[]float64{1, -2.2179736413348135, 1.9770728417085599, -0.82511241746378239, 0.13461194177670549}},
+		{3, "delay", []float64{0.10193658742919456, 0.30580976228758372, 0.30580976228758372, 0.10193658742919456},
+			[]float64{1, -0.36102047562377415, 0.20019422826451597, -0.023681053207184981}},
+		{4, "delay", []float64{0.084975751549448225, 0.3399030061977929, 0.5098545092966893, 0.3399030061977929, 0.084975751549448225},
+			[]float64{1, 0.10066257213010373, 0.25560375983837857, -0.001743413662072894, 0.0050891064847625091}},
+		{3, "mag", []float64{0.034965550946145792, 0.10489665283843738, 0.10489665283843738, 0.034965550946145792},
+			[]float64{1, -1.2177696738971333, 0.61085794294083162, -0.11336386147453187}},
+		{4, "mag", []float64{0.014506121931873369, 0.058024487727493475, 0.087036731591240216, 0.058024487727493475, 0.014506121931873369},
+			[]float64{1, -1.5042033314996293, 1.0458620166532835, -0.35990702743352465, 0.050346293189844488}},
+	}
+	for _, ref := range references {
+		b, a := Bessel([]float64{0.2}, ref.order, "lowpass", ref.norm)
+		if !AllClose(ref.b, b, 1e-10) || !AllClose(ref.a, a, 1e-10) {
+			t.Errorf("Bessel(0.2,%d,%s) = %v, %v, want %v, %v", ref.order, ref.norm, b, a, ref.b, ref.a)
+		}
+	}
+
+	// the delay normalized prototype of order 2 has poles at the roots of s^2 + 3s + 3
+	_, p, k := besselap(2, "delay")
+	expected := []complex128{complex(-1.5, math.Sqrt(3)/2), complex(-1.5, -math.Sqrt(3)/2)}
+	for _, want := range expected {
+		found := false
+		for _, pole := range p {
+			if cmplx.Abs(pole-want) < 1e-12 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("besselap(2, delay) poles = %v, want %v", p, expected)
+		}
+	}
+	if math.Abs(k-3) > 1e-12 {
+		t.Errorf("besselap(2, delay) gain = %v, want 3", k)
+	}
+
+	// the group delay of the delay normalized prototype is 1 at DC for every order
+	for _, order := range []int{1, 4, 9, 12} {
+		_, p, _ := besselap(order, "delay")
+		var delay float64
+		for _, pole := range p {
+			delay += real(-1 / pole)
+		}
+		if math.Abs(delay-1) > 1e-10 {
+			t.Errorf("besselap(%d, delay) group delay = %v, want 1", order, delay)
+		}
+	}
+
+	// the poles closest to the real axis are badly conditioned at high orders, the reference values were
+	// computed with 80 digit arithmetic
+	_, p, _ = besselap(25, "delay")
+	for _, want := range []complex128{
+		complex(-16.900313864686478, 0),
+		complex(-16.838322031500798, 1.7358418756062886),
+		complex(-16.336025000097457, -5.219102091196987),
+		complex(-4.426049574071369, 22.59343286760654),
+	} {
+		found := false
+		for _, pole := range p {
+			if cmplx.Abs(pole-want) < 1e-12*cmplx.Abs(want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("besselap(25, delay) has no pole at %v", want)
+		}
+	}
+
+	for _, order := range []int{1, 3, 6, 10} {
+		// "mag" places the -3 dB point at Wn
+		b, a := Bessel([]float64{0.2}, order, "lowpass", "mag")
+		if mag := freqResponse(b, a, 0.2); math.Abs(mag-1/math.Sqrt2) > 1e-10 {
+			t.Errorf("Bessel(0.2,%d,mag) gain at Wn = %v, want %v", order, mag, 1/math.Sqrt2)
+		}
+
+		// "phase" shares its high frequency asymptote with the Butterworth filter
+		_, p, k := besselap(order, "phase")
+		w := 1e4
+		gain := k
+		for _, pole := range p {
+			gain *= w / cmplx.Abs(complex(0, w)-pole)
+		}
+		if math.Abs(gain-1) > 1e-3 {
+			t.Errorf("besselap(%d, phase) asymptotic gain = %v, want 1", order, gain)
+		}
+		b, a = Bessel([]float64{0.2}, order, "lowpass", "phase")
+		if mag := freqResponse(b, a, 0); math.Abs(mag-1) > 1e-10 {
+			t.Errorf("Bessel(0.2,%d,phase) DC gain = %v, want 1", order, mag)
+		}
+	}
+
+	for _, btype := range []string{"highpass", "bandpass", "bandstop"} {
+		Wn := []float64{0.4}
+		if btype != "highpass" {
+			Wn = []float64{0.2, 0.5}
+		}
+		b, a := Bessel(Wn, 4, btype, "mag")
+		for _, w := range Wn {
+			if mag := freqResponse(b, a, w); math.Abs(mag-1/math.Sqrt2) > 1e-10 {
+				t.Errorf("Bessel(%v,4,%s,mag) gain at %v = %v", Wn, btype, w, mag)
+			}
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package vectors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// splineOrders maps the interp1d kinds implemented with interpolating B-splines to their degree
+var splineOrders = map[string]int{
+	"zero":      0,
+	"slinear":   1,
+	"quadratic": 2,
+	"cubic":     3,
+}
+
+// Interp1DOptions configures a 1-D interpolator. The zero value gives linear interpolation that returns
+// an error for points outside the range of the data, as scipy.interpolate.interp1d does by default.
+type Interp1DOptions struct {
+	// Kind is one of "linear" (default), "nearest", "nearest-up", "zero", "slinear", "quadratic",
+	// "cubic", "previous" or "next".
+	Kind string
+	// FillValue is used for points outside the data range. A single value is used on both sides,
+	// two values give the fill below and above the range. Setting it disables the bounds error.
+	FillValue []float64
+	// Extrapolate evaluates points outside the data range with the interpolant of the nearest interval.
+	Extrapolate bool
+	// BoundsError forces an error for points outside the data range even when FillValue is set.
+	BoundsError bool
+}
+
+// Interpolator1D interpolates a 1-D function y = f(x) from sample points.
+type Interpolator1D struct {
+	x, y       []float64
+	kind       string
+	opts       Interp1DOptions
+	knots      []float64
+	coeffs     []float64
+	splineDeg  int
+	belowValue float64
+	aboveValue float64
+}
+
+// NewInterp1D returns an interpolator for the samples x and y. The samples do not need to be sorted.
+func NewInterp1D(x, y []float64, opts Interp1DOptions) (*Interpolator1D, error) {
+	kind := opts.Kind
+	if kind == "" {
+		kind = "linear"
+	}
+	minPoints := 2
+	switch kind {
+	case "linear":
+	case "nearest", "nearest-up", "previous", "next":
+		minPoints = 1
+	default:
+		order, ok := splineOrders[kind]
+		if !ok {
+			return nil, fmt.Errorf("interp1d: unsupported kind %q", kind)
+		}
+		minPoints = order + 1
+	}
+	switch {
+	case len(x) != len(y):
+		return nil, fmt.Errorf("interp1d: %w: x and y must have the same length", ErrShapeMismatch)
+	case len(x) < minPoints:
+		return nil, fmt.Errorf("interp1d: %s interpolation requires at least %d points", kind, minPoints)
+	case len(opts.FillValue) > 2:
+		return nil, errors.New("interp1d: fill value must have one or two elements")
+	}
+
+	// sort the samples by x, keeping the order of equal abscissae
+	indices := make([]int, len(x))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return x[indices[i]] < x[indices[j]] })
+	interp := &Interpolator1D{kind: kind, opts: opts}
+	for _, i := range indices {
+		interp.x = append(interp.x, x[i])
+		interp.y = append(interp.y, y[i])
+	}
+
+	interp.belowValue, interp.aboveValue = math.NaN(), math.NaN()
+	if len(opts.FillValue) > 0 {
+		interp.belowValue = opts.FillValue[0]
+		interp.aboveValue = opts.FillValue[len(opts.FillValue)-1]
+	}
+	if order, ok := splineOrders[kind]; ok {
+		knots, coeffs, err := makeInterpSpline(interp.x, interp.y, order)
+		if err != nil {
+			return nil, err
+		}
+		interp.knots, interp.coeffs, interp.splineDeg = knots, coeffs, order
+	}
+	return interp, nil
+}
+
+// Interp1D interpolates the samples x and y at the points xi.
+func Interp1D(x, y, xi []float64, opts Interp1DOptions) ([]float64, error) {
+	interp, err := NewInterp1D(x, y, opts)
+	if err != nil {
+		return nil, err
+	}
+	return interp.Eval(xi)
+}
+
+// Eval returns the interpolated values at the points xi.
+func (interp *Interpolator1D) Eval(xi []float64) ([]float64, error) {
+	x, y := interp.x, interp.y
+	n := len(x)
+	boundsError := interp.opts.BoundsError || (interp.opts.FillValue == nil && !interp.opts.Extrapolate)
+
+	result := make([]float64, len(xi))
+	for i, v := range xi {
+		below, above := v < x[0], v > x[n-1]
+		if below || above {
+			if boundsError {
+				return nil, fmt.Errorf("interp1d: value %v is outside the interpolation range [%v, %v]", v, x[0], x[n-1])
+			}
+			if !interp.opts.Extrapolate {
+				if below {
+					result[i] = interp.belowValue
+				} else {
+					result[i] = interp.aboveValue
+				}
+				continue
+			}
+		}
+
+		switch interp.kind {
+		case "linear":
+			hi := sort.SearchFloat64s(x, v)
+			if hi < 1 {
+				hi = 1
+			}
+			if hi > n-1 {
+				hi = n - 1
+			}
+			lo := hi - 1
+			slope := (y[hi] - y[lo]) / (x[hi] - x[lo])
+			result[i] = slope*(v-x[lo]) + y[lo]
+		case "nearest", "nearest-up":
+			// the boundaries between samples are the midpoints, rounding down or up on ties
+			idx := sort.Search(n-1, func(j int) bool {
+				mid := (x[j] + x[j+1]) / 2
+				if interp.kind == "nearest" {
+					return mid >= v
+				}
+				return mid > v
+			})
+			result[i] = y[idx]
+		case "previous":
+			if below {
+				result[i] = math.NaN()
+				continue
+			}
+			idx := sort.Search(n, func(j int) bool { return x[j] > v })
+			result[i] = y[idx-1]
+		case "next":
+			if above {
+				result[i] = math.NaN()
+				continue
+			}
+			idx := sort.Search(n, func(j int) bool { return x[j] >= v })
+			result[i] = y[idx]
+		default:
+			result[i] = evalBSpline(interp.knots, interp.coeffs, interp.splineDeg, v)
+		}
+	}
+	return result, nil
+}
+
+// makeInterpSpline returns the knots and coefficients of the degree k B-spline interpolating x and y,
+// using the not-a-knot conditions of scipy.interpolate.make_interp_spline
+func makeInterpSpline(x, y []float64, k int) ([]float64, []float64, error) {
+	n := len(x)
+	for i := 1; i < n; i++ {
+		if x[i] == x[i-1] {
+			return nil, nil, errors.New("interp1d: spline interpolation requires distinct x values")
+		}
+	}
+
+	var knots []float64
+	switch k {
+	case 0:
+		knots = append(append(knots, x...), x[n-1])
+		return knots, append([]float64{}, y...), nil
+	case 2:
+		// Greville sites omitting the second and second-to-last points, a la not-a-knot
+		knots = Repeat(x[0], k+1)
+		for i := 2; i < n-1; i++ {
+			knots = append(knots, (x[i]+x[i-1])/2)
+		}
+		knots = append(knots, Repeat(x[n-1], k+1)...)
+	default:
+		m := (k - 1) / 2
+		knots = Repeat(x[0], k+1)
+		knots = append(knots, x[m+1:n-m-1]...)
+		knots = append(knots, Repeat(x[n-1], k+1)...)
+	}
+
+	// the collocation matrix is banded, row i holds the k+1 basis functions nonzero at x[i]
+	rows := make([]sparseRow, n)
+	for i, v := range x {
+		l := findKnotInterval(knots, k, v)
+		rows[i] = sparseRow{start: l - k, values: bsplineBasis(knots, k, l, v)}
+	}
+	coeffs, err := solveSparseRows(rows, append([]float64{}, y...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return knots, coeffs, nil
+}
+
+// sparseRow is a matrix row whose nonzero entries lie in the columns start to start+len(values)-1
+type sparseRow struct {
+	start  int
+	values []float64
+}
+
+// at returns the entry of the row in column j
+func (row sparseRow) at(j int) float64 {
+	if j < row.start || j >= row.start+len(row.values) {
+		return 0
+	}
+	return row.values[j-row.start]
+}
+
+// solveSparseRows solves a linear system given by rows with contiguous nonzero entries whose first
+// columns do not decrease, such as a banded matrix, using Gaussian elimination with partial pivoting
+func solveSparseRows(rows []sparseRow, rhs []float64) ([]float64, error) {
+	n := len(rows)
+	for col := 0; col < n; col++ {
+		// the candidate pivots are the consecutive rows that reach this column
+		last := col
+		for last+1 < n && rows[last+1].start <= col {
+			last++
+		}
+		pivot := col
+		for r := col + 1; r <= last; r++ {
+			if math.Abs(rows[r].at(col)) > math.Abs(rows[pivot].at(col)) {
+				pivot = r
+			}
+		}
+		if rows[pivot].at(col) == 0 {
+			return nil, fmt.Errorf("interp1d: %w: the collocation matrix cannot be solved", ErrSingular)
+		}
+		rows[col], rows[pivot] = rows[pivot], rows[col]
+		rhs[col], rhs[pivot] = rhs[pivot], rhs[col]
+
+		pivotRow := rows[col]
+		end := pivotRow.start + len(pivotRow.values)
+		for r := col + 1; r <= last; r++ {
+			factor := rows[r].at(col) / pivotRow.at(col)
+			rowEnd := int(math.Max(float64(end), float64(rows[r].start+len(rows[r].values))))
+			values := make([]float64, rowEnd-col-1)
+			for j := col + 1; j < rowEnd; j++ {
+				values[j-col-1] = rows[r].at(j) - factor*pivotRow.at(j)
+			}
+			rows[r] = sparseRow{start: col + 1, values: values}
+			rhs[r] -= factor * rhs[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := rhs[i]
+		row := rows[i]
+		for j := i + 1; j < row.start+len(row.values) && j < n; j++ {
+			sum -= row.at(j) * x[j]
+		}
+		x[i] = sum / row.at(i)
+	}
+	return x, nil
+}
+
+// findKnotInterval returns the index l with knots[l] <= x < knots[l+1] among the intervals spanned by
+// the spline, clamping points outside the base interval to the first or last interval
+func findKnotInterval(knots []float64, k int, x float64) int {
+	n := len(knots) - k - 1
+	if x >= knots[n] {
+		return n - 1
+	}
+	l := sort.Search(len(knots), func(i int) bool { return knots[i] > x }) - 1
+	if l < k {
+		l = k
+	}
+	if l > n-1 {
+		l = n - 1
+	}
+	return l
+}
+
+// bsplineBasis returns the values of the k+1 B-spline basis functions that are nonzero on the knot
+// interval l, evaluated at x with the Cox-de Boor recursion
+func bsplineBasis(knots []float64, k, l int, x float64) []float64 {
+	basis := make([]float64, k+1)
+	basis[0] = 1
+	left := make([]float64, k+1)
+	right := make([]float64, k+1)
+	for j := 1; j <= k; j++ {
+		left[j] = x - knots[l+1-j]
+		right[j] = knots[l+j] - x
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			temp := basis[r] / (right[r+1] + left[j-r])
+			basis[r] = saved + right[r+1]*temp
+			saved = left[j-r] * temp
+		}
+		basis[j] = saved
+	}
+	return basis
+}
+
+// evalBSpline evaluates the B-spline with the given knots, coefficients and degree at x
+func evalBSpline(knots, coeffs []float64, k int, x float64) float64 {
+	l := findKnotInterval(knots, k, x)
+	basis := bsplineBasis(knots, k, l, x)
+	var value float64
+	for j := 0; j <= k; j++ {
+		value += coeffs[l-k+j] * basis[j]
+	}
+	return value
+}
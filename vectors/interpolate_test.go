@@ -0,0 +1,114 @@
+package vectors
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestInterp1D(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5}
+	xi := []float64{0, 0.5, 1.25, 2.5, 4.75, 5}
+	cube := func(v float64) float64 { return v*v*v - 2*v }
+	square := func(v float64) float64 { return 3*v*v - v + 1 }
+
+	// splines reproduce polynomials up to their degree
+	for _, c := range []struct {
+		kind string
+		f    func(float64) float64
+	}{
+		{"linear", func(v float64) float64 { return 2*v - 1 }},
+		{"slinear", func(v float64) float64 { return 2*v - 1 }},
+		{"quadratic", square},
+		{"cubic", cube},
+	} {
+		output, err := Interp1D(x, Apply(x, c.f), xi, Interp1DOptions{Kind: c.kind})
+		if err != nil {
+			t.Fatalf("Interp1D(%s) returned error %v", c.kind, err)
+		}
+		if !AllClose(Apply(xi, c.f), output, 1e-12) {
+			t.Errorf("Interp1D(%s) = %v, want %v", c.kind, output, Apply(xi, c.f))
+		}
+	}
+
+	y := []float64{10, 20, 30, 40, 50, 60}
+	xi = []float64{0.5, 1.2, 2.7, 5}
+	for kind, expected := range map[string][]float64{
+		"nearest":    {10, 20, 40, 60},
+		"nearest-up": {20, 20, 40, 60},
+		"previous":   {10, 20, 30, 60},
+		"next":       {20, 30, 40, 60},
+		"zero":       {10, 20, 30, 60},
+	} {
+		output, err := Interp1D(x, y, xi, Interp1DOptions{Kind: kind})
+		if err != nil || !reflect.DeepEqual(expected, output) {
+			t.Errorf("Interp1D(%s) = %v, %v, want %v", kind, output, err, expected)
+		}
+	}
+
+	// unsorted samples are sorted before interpolating
+	output, err := Interp1D([]float64{2, 0, 1}, []float64{4, 0, 1}, []float64{1.5}, Interp1DOptions{})
+	if err != nil || !AllClose([]float64{2.5}, output, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", output, err, []float64{2.5})
+	}
+}
+
+func TestInterp1DBounds(t *testing.T) {
+	x := []float64{1, 2, 3}
+	y := []float64{3, 2, 0}
+	xi := []float64{0, 2.5, 4}
+
+	if _, err := Interp1D(x, y, xi, Interp1DOptions{}); err == nil {
+		t.Errorf("Interp1D outside the data range returned no error")
+	}
+	if _, err := Interp1D(x, y, xi, Interp1DOptions{FillValue: []float64{0}, BoundsError: true}); err == nil {
+		t.Errorf("Interp1D with BoundsError returned no error")
+	}
+
+	output, err := Interp1D(x, y, xi, Interp1DOptions{FillValue: []float64{-1, 99}})
+	expected := []float64{-1, 1, 99}
+	if err != nil || !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, %v, want %v", output, err, expected)
+	}
+
+	output, _ = Interp1D(x, y, xi, Interp1DOptions{FillValue: []float64{math.NaN()}})
+	if !math.IsNaN(output[0]) || !math.IsNaN(output[2]) {
+		t.Errorf("Got %v, want NaN outside the data range", output)
+	}
+
+	output, err = Interp1D(x, y, xi, Interp1DOptions{Extrapolate: true})
+	expected = []float64{4, 1, -2}
+	if err != nil || !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", output, err, expected)
+	}
+
+	output, _ = Interp1D(x, y, xi, Interp1DOptions{Kind: "previous", Extrapolate: true})
+	if !math.IsNaN(output[0]) || output[1] != 2 || output[2] != 0 {
+		t.Errorf("Got %v, want [NaN 2 0]", output)
+	}
+}
+
+func TestNewInterp1D(t *testing.T) {
+	if _, err := NewInterp1D([]float64{1, 2}, []float64{1}, Interp1DOptions{}); err == nil {
+		t.Errorf("NewInterp1D with mismatched lengths returned no error")
+	}
+	if _, err := NewInterp1D([]float64{1, 2, 3}, []float64{1, 2, 3}, Interp1DOptions{Kind: "cubic"}); err == nil {
+		t.Errorf("NewInterp1D with too few points for a cubic returned no error")
+	}
+	if _, err := NewInterp1D([]float64{1, 2}, []float64{1, 2}, Interp1DOptions{Kind: "septic"}); err == nil {
+		t.Errorf("NewInterp1D with an unknown kind returned no error")
+	}
+
+	// a cubic spline through a long record stays accurate
+	x := Arange(0.0, 2000, 1)
+	f := func(v float64) float64 { return math.Sin(v / 50) }
+	interp, err := NewInterp1D(x, Apply(x, f), Interp1DOptions{Kind: "cubic"})
+	if err != nil {
+		t.Fatalf("NewInterp1D returned error %v", err)
+	}
+	xi := []float64{10.5, 999.25, 1998.5}
+	output, _ := interp.Eval(xi)
+	if !AllClose(Apply(xi, f), output, 1e-8) {
+		t.Errorf("Got %v, want %v", output, Apply(xi, f))
+	}
+}
@@ -0,0 +1,1202 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// Inv returns the inverse of a square matrix computed from its LU decomposition with partial pivoting.
+// It returns ErrSingular when a pivot is exactly zero, as LAPACK's getrf does.
+func Inv(array [][]float64) ([][]float64, error) {
+	if err := checkSquare("inv", array); err != nil {
+		return nil, err
+	}
+	lu, perm, _ := luDecompose(array)
+	for i := range lu {
+		if lu[i][i] == 0 {
+			return nil, fmt.Errorf("inv: %w", ErrSingular)
+		}
+	}
+	return luInverse(lu, perm), nil
+}
+
+// Det returns the determinant of a square matrix, the product of the pivots of its LU decomposition.
+func Det(array [][]float64) (float64, error) {
+	if err := checkSquare("det", array); err != nil {
+		return 0, err
+	}
+	lu, _, det := luDecompose(array)
+	for i := range lu {
+		det *= lu[i][i]
+	}
+	return det, nil
+}
+
+// Solve returns the solution x of the linear system a x = b, using the LU decomposition of a instead of
+// its inverse.
+func Solve(a [][]float64, b []float64) ([]float64, error) {
+	if err := checkSquare("solve", a); err != nil {
+		return nil, err
+	}
+	if len(b) != len(a) {
+		return nil, fmt.Errorf("solve: %w: a has %d rows and b has %d elements", ErrShapeMismatch, len(a), len(b))
+	}
+	lu, perm, _ := luDecompose(a)
+	for i := range lu {
+		if lu[i][i] == 0 {
+			return nil, fmt.Errorf("solve: %w", ErrSingular)
+		}
+	}
+	return luSolve(lu, perm, b), nil
+}
+
+// checkSquare returns ErrEmpty or ErrShapeMismatch unless array is a non-empty square matrix
+func checkSquare(name string, array [][]float64) error {
+	if len(array) == 0 {
+		return fmt.Errorf("%s: %w: the matrix has no rows", name, ErrEmpty)
+	}
+	for _, row := range array {
+		if len(row) != len(array) {
+			return fmt.Errorf("%s: %w: the matrix must be square", name, ErrShapeMismatch)
+		}
+	}
+	return nil
+}
+
+// luDecompose factors a square matrix as P a = L U with partial pivoting. L, with a unit diagonal, and U
+// are packed into the returned matrix, row i of P a is row perm[i] of a, and the sign of the permutation
+// is returned last. Columns without a nonzero pivot are left uneliminated, giving a zero on the diagonal.
+func luDecompose(array [][]float64) ([][]float64, []int, float64) {
+	n := len(array)
+	lu := make([][]float64, n)
+	perm := make([]int, n)
+	for i := range array {
+		lu[i] = append([]float64{}, array[i]...)
+		perm[i] = i
+	}
+	sign := 1.0
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(lu[r][col]) > math.Abs(lu[pivot][col]) {
+				pivot = r
+			}
+		}
+		if pivot != col {
+			lu[col], lu[pivot] = lu[pivot], lu[col]
+			perm[col], perm[pivot] = perm[pivot], perm[col]
+			sign = -sign
+		}
+		if lu[col][col] == 0 {
+			continue
+		}
+		for r := col + 1; r < n; r++ {
+			f := lu[r][col] / lu[col][col]
+			lu[r][col] = f
+			if f == 0 {
+				continue
+			}
+			for j := col + 1; j < n; j++ {
+				lu[r][j] -= f * lu[col][j]
+			}
+		}
+	}
+	return lu, perm, sign
+}
+
+// luSolve solves a x = b given the packed LU decomposition of a with nonzero pivots
+func luSolve(lu [][]float64, perm []int, b []float64) []float64 {
+	n := len(lu)
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[perm[i]]
+		for j := 0; j < i; j++ {
+			sum -= lu[i][j] * x[j]
+		}
+		x[i] = sum
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= lu[i][j] * x[j]
+		}
+		x[i] = sum / lu[i][i]
+	}
+	return x
+}
+
+// luInverse returns the inverse of a matrix from its packed LU decomposition by solving for each column
+// of the identity
+func luInverse(lu [][]float64, perm []int) [][]float64 {
+	n := len(lu)
+	inv := Zeros(n, n)
+	unit := make([]float64, n)
+	for j := 0; j < n; j++ {
+		unit[j] = 1
+		column := luSolve(lu, perm, unit)
+		unit[j] = 0
+		for i := 0; i < n; i++ {
+			inv[i][j] = column[i]
+		}
+	}
+	return inv
+}
+
+// invertMatrix returns the inverse of a square matrix and false if the matrix is numerically singular,
+// that is if a pivot of its LU decomposition is negligible relative to the largest element
+func invertMatrix(array [][]float64) ([][]float64, bool) {
+	n := len(array)
+	var scale float64
+	for i := range array {
+		for _, v := range array[i] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	lu, perm, _ := luDecompose(array)
+	for i := range lu {
+		if math.Abs(lu[i][i]) <= 2.220446049250313e-16*float64(n)*scale {
+			return nil, false
+		}
+	}
+	return luInverse(lu, perm), true
+}
+
+// checkMatrix returns ErrEmpty or ErrShapeMismatch unless array is a non-empty matrix with rows of
+// equal length
+func checkMatrix(name string, array [][]float64) error {
+	if len(array) == 0 || len(array[0]) == 0 {
+		return fmt.Errorf("%s: %w: the matrix has no elements", name, ErrEmpty)
+	}
+	if !CheckConsistency(array) {
+		return fmt.Errorf("%s: %w: all rows must have the same length", name, ErrShapeMismatch)
+	}
+	return nil
+}
+
+// LU returns the LU decomposition with partial pivoting of a square matrix as a permutation matrix p, a
+// unit lower triangular l and an upper triangular u with a = p l u, like scipy.linalg.lu.
+func LU(array [][]float64) ([][]float64, [][]float64, [][]float64, error) {
+	if err := checkSquare("lu", array); err != nil {
+		return nil, nil, nil, err
+	}
+	n := len(array)
+	lu, perm, _ := luDecompose(array)
+	p, l, u := Zeros(n, n), Zeros(n, n), Zeros(n, n)
+	for i := 0; i < n; i++ {
+		p[perm[i]][i] = 1
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				l[i][j] = lu[i][j]
+			case j == i:
+				l[i][j] = 1
+				u[i][j] = lu[i][j]
+			default:
+				u[i][j] = lu[i][j]
+			}
+		}
+	}
+	return p, l, u, nil
+}
+
+// QR returns the reduced QR decomposition a = q r of an m by n matrix computed with Householder
+// reflections: q is m by k with orthonormal columns and r is k by n upper triangular, k = min(m, n). The
+// reflections are chosen as in LAPACK's geqrf, so the signs match numpy.linalg.qr.
+func QR(array [][]float64) ([][]float64, [][]float64, error) {
+	if err := checkMatrix("qr", array); err != nil {
+		return nil, nil, err
+	}
+	m, n := len(array), len(array[0])
+	k := int(math.Min(float64(m), float64(n)))
+	r := make([][]float64, m)
+	for i := range array {
+		r[i] = append([]float64{}, array[i]...)
+	}
+
+	// the reflection j is I - tau[j] v[j] v[j]^T with v[j][0] = 1, acting on rows j to m-1
+	v := make([][]float64, k)
+	tau := make([]float64, k)
+	for j := 0; j < k; j++ {
+		alpha := r[j][j]
+		var xnorm float64
+		for i := j + 1; i < m; i++ {
+			xnorm = math.Hypot(xnorm, r[i][j])
+		}
+		v[j] = make([]float64, m-j)
+		v[j][0] = 1
+		if xnorm == 0 {
+			continue
+		}
+		beta := -math.Copysign(math.Hypot(alpha, xnorm), alpha)
+		tau[j] = (beta - alpha) / beta
+		for i := j + 1; i < m; i++ {
+			v[j][i-j] = r[i][j] / (alpha - beta)
+		}
+		applyReflection(r, v[j], tau[j], j, j)
+	}
+
+	// accumulate q from the identity, applying the reflections in reverse order
+	q := Zeros(m, k)
+	for i := 0; i < k; i++ {
+		q[i][i] = 1
+	}
+	for j := k - 1; j >= 0; j-- {
+		applyReflection(q, v[j], tau[j], j, j)
+	}
+
+	rk := Zeros(k, n)
+	for i := 0; i < k; i++ {
+		for j := i; j < n; j++ {
+			rk[i][j] = r[i][j]
+		}
+	}
+	return q, rk, nil
+}
+
+// applyReflection multiplies the rows from row0 and the columns from col0 of a in place by the
+// Householder reflection I - tau v v^T
+func applyReflection(a [][]float64, v []float64, tau float64, row0, col0 int) {
+	if tau == 0 {
+		return
+	}
+	for j := col0; j < len(a[0]); j++ {
+		var dot float64
+		for i := range v {
+			dot += v[i] * a[row0+i][j]
+		}
+		dot *= tau
+		for i := range v {
+			a[row0+i][j] -= dot * v[i]
+		}
+	}
+}
+
+// Cholesky returns the lower triangular l with a = l l^T of a symmetric positive definite matrix, reading
+// only the lower triangle of a like numpy.linalg.cholesky.
+func Cholesky(array [][]float64) ([][]float64, error) {
+	if err := checkSquare("cholesky", array); err != nil {
+		return nil, err
+	}
+	n := len(array)
+	l := Zeros(n, n)
+	for j := 0; j < n; j++ {
+		d := array[j][j]
+		for k := 0; k < j; k++ {
+			d -= l[j][k] * l[j][k]
+		}
+		if !(d > 0) {
+			return nil, fmt.Errorf("cholesky: %w: leading minor of order %d", ErrNotPositiveDefinite, j+1)
+		}
+		l[j][j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			sum := array[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			l[i][j] = sum / l[j][j]
+		}
+	}
+	return l, nil
+}
+
+// SVD returns the reduced singular value decomposition a = u diag(s) vt of an m by n matrix: u is m by k
+// with orthonormal columns, s holds the k = min(m, n) singular values in descending order and vt is k by n
+// with orthonormal rows, as numpy.linalg.svd with full_matrices=False. It uses one-sided Jacobi rotations,
+// which compute small singular values to high relative accuracy.
+func SVD(array [][]float64) ([][]float64, []float64, [][]float64, error) {
+	if err := checkMatrix("svd", array); err != nil {
+		return nil, nil, nil, err
+	}
+	m, n := len(array), len(array[0])
+	if m < n {
+		// the decomposition of the transpose gives a = v s u^T
+		u, s, vt, err := SVD(Transpose(array))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return Transpose(vt), s, Transpose(u), nil
+	}
+
+	// rotate pairs of columns of w = a v until they are mutually orthogonal
+	w := make([][]float64, m)
+	for i := range array {
+		w[i] = append([]float64{}, array[i]...)
+	}
+	v := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		v[i][i] = 1
+	}
+	eps := 2.220446049250313e-16
+	var total float64
+	for i := range array {
+		for _, value := range array[i] {
+			total = math.Hypot(total, value)
+		}
+	}
+	// A rank deficient matrix leaves columns of w that are only rounding noise, with a norm of about eps
+	// times the norm of a. Rotating them against each other keeps changing their directions without ever
+	// passing the orthogonality test, so the sweeps would not converge: such columns are left alone and
+	// give zero singular values. The test itself allows the rounding error of inner products of m terms.
+	negligible := eps * total * eps * total
+	converged := false
+	for sweep := 0; sweep < 100 && !converged; sweep++ {
+		converged = true
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < m; i++ {
+					alpha += w[i][p] * w[i][p]
+					beta += w[i][q] * w[i][q]
+					gamma += w[i][p] * w[i][q]
+				}
+				if alpha <= negligible || beta <= negligible || math.Abs(gamma) <= float64(m)*eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				converged = false
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+				rotateColumns(w, p, q, c, s)
+				rotateColumns(v, p, q, c, s)
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, nil, fmt.Errorf("svd: %w", ErrNotConverged)
+	}
+
+	// the singular values are the column norms, sorted in descending order
+	s := make([]float64, n)
+	order := make([]int, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			s[j] = math.Hypot(s[j], w[i][j])
+		}
+		if s[j]*s[j] <= negligible {
+			s[j] = 0
+		}
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return s[order[a]] > s[order[b]] })
+
+	u := Zeros(m, n)
+	vt := Zeros(n, n)
+	sorted := make([]float64, n)
+	for k, j := range order {
+		sorted[k] = s[j]
+		for i := 0; i < m; i++ {
+			if s[j] > 0 {
+				u[i][k] = w[i][j] / s[j]
+			}
+		}
+		for i := 0; i < n; i++ {
+			vt[k][i] = v[i][j]
+		}
+	}
+	completeOrthonormal(u, sorted)
+	return u, sorted, vt, nil
+}
+
+// rotateColumns applies the plane rotation [c s; -s c] to the columns p and q of a in place
+func rotateColumns(a [][]float64, p, q int, c, s float64) {
+	for i := range a {
+		ap, aq := a[i][p], a[i][q]
+		a[i][p] = c*ap - s*aq
+		a[i][q] = s*ap + c*aq
+	}
+}
+
+// completeOrthonormal replaces the columns of u that belong to zero singular values, which are zero and
+// undetermined, with unit vectors orthogonal to the other columns using Gram-Schmidt on the standard basis
+func completeOrthonormal(u [][]float64, s []float64) {
+	m := len(u)
+	candidate := 0
+	for k := range s {
+		if s[k] > 0 {
+			continue
+		}
+		for ; candidate < m; candidate++ {
+			column := make([]float64, m)
+			column[candidate] = 1
+			// orthogonalize twice for numerical stability
+			for pass := 0; pass < 2; pass++ {
+				for j := range s {
+					if j == k {
+						continue
+					}
+					var dot float64
+					for i := 0; i < m; i++ {
+						dot += u[i][j] * column[i]
+					}
+					for i := 0; i < m; i++ {
+						column[i] -= dot * u[i][j]
+					}
+				}
+			}
+			if norm := Norm(column); norm > 1e-8 {
+				for i := 0; i < m; i++ {
+					u[i][k] = column[i] / norm
+				}
+				candidate++
+				break
+			}
+		}
+	}
+}
+
+// Eig returns the eigenvalues and the right eigenvectors, as the columns of the second result, of a
+// general square matrix like numpy.linalg.eig. Each eigenvector has unit norm and its component of
+// largest modulus is real, following LAPACK's geev.
+func Eig(array [][]float64) ([]complex128, [][]complex128, error) {
+	if err := checkSquare("eig", array); err != nil {
+		return nil, nil, err
+	}
+	values, vectors, err := eigenNonsymmetric(array)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := len(array)
+	for j := 0; j < n; j++ {
+		var norm float64
+		largest := vectors[0][j]
+		for i := 0; i < n; i++ {
+			norm = math.Hypot(norm, cmplx.Abs(vectors[i][j]))
+			if cmplx.Abs(vectors[i][j]) > cmplx.Abs(largest) {
+				largest = vectors[i][j]
+			}
+		}
+		if norm == 0 {
+			continue
+		}
+		// scale by the conjugate phase of the largest component so that it becomes real
+		phase := complex(1, 0)
+		if imag(largest) != 0 {
+			phase = cmplx.Conj(largest) / complex(cmplx.Abs(largest), 0)
+		}
+		for i := 0; i < n; i++ {
+			vectors[i][j] = vectors[i][j] * phase / complex(norm, 0)
+		}
+	}
+	return values, vectors, nil
+}
+
+// eigenNonsymmetric returns the eigenvalues and eigenvectors (as columns) of a general square matrix.
+// The matrix is balanced, reduced to Hessenberg form and then to real Schur form with the shifted QR
+// algorithm, following the EISPACK routines balanc, orthes and hqr2.
+func eigenNonsymmetric(array [][]float64) ([]complex128, [][]complex128, error) {
+	n := len(array)
+	h := make([][]float64, n)
+	for i := range array {
+		h[i] = append([]float64{}, array[i]...)
+	}
+	scale := balance(h)
+	v := orthes(h)
+	d, e, err := hqr2(h, v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]complex128, n)
+	vectors := make([][]complex128, n)
+	for i := range vectors {
+		vectors[i] = make([]complex128, n)
+	}
+	for j := 0; j < n; j++ {
+		values[j] = complex(d[j], e[j])
+		switch {
+		case e[j] == 0:
+			for i := 0; i < n; i++ {
+				vectors[i][j] = complex(v[i][j]*scale[i], 0)
+			}
+		case e[j] > 0:
+			// columns j and j+1 hold the real and imaginary parts of a conjugate pair
+			for i := 0; i < n; i++ {
+				vectors[i][j] = complex(v[i][j]*scale[i], v[i][j+1]*scale[i])
+				vectors[i][j+1] = complex(v[i][j]*scale[i], -v[i][j+1]*scale[i])
+			}
+		}
+	}
+	return values, vectors, nil
+}
+
+// balance scales the rows and columns of a square matrix in place so that their norms are comparable,
+// which improves the accuracy of the computed eigenvalues, and returns the diagonal similarity transform
+func balance(a [][]float64) []float64 {
+	const radix = 2.0
+	n := len(a)
+	scale := Ones(n)
+	done := false
+	for !done {
+		done = true
+		for i := 0; i < n; i++ {
+			var r, c float64
+			for j := 0; j < n; j++ {
+				if j != i {
+					c += math.Abs(a[j][i])
+					r += math.Abs(a[i][j])
+				}
+			}
+			if c == 0 || r == 0 {
+				continue
+			}
+			g := r / radix
+			f := 1.0
+			s := c + r
+			for c < g {
+				f *= radix
+				c *= radix * radix
+			}
+			g = r * radix
+			for c > g {
+				f /= radix
+				c /= radix * radix
+			}
+			if (c+r)/f < 0.95*s {
+				done = false
+				scale[i] *= f
+				for j := 0; j < n; j++ {
+					a[i][j] /= f
+					a[j][i] *= f
+				}
+			}
+		}
+	}
+	return scale
+}
+
+// orthes reduces a square matrix in place to upper Hessenberg form with Householder similarity
+// transformations and returns the accumulated orthogonal transformation
+func orthes(h [][]float64) [][]float64 {
+	n := len(h)
+	low, high := 0, n-1
+	ort := make([]float64, n)
+
+	for m := low + 1; m <= high-1; m++ {
+		var scale float64
+		for i := m; i <= high; i++ {
+			scale += math.Abs(h[i][m-1])
+		}
+		if scale == 0 {
+			continue
+		}
+
+		// compute the Householder transformation
+		var hh float64
+		for i := high; i >= m; i-- {
+			ort[i] = h[i][m-1] / scale
+			hh += ort[i] * ort[i]
+		}
+		g := math.Sqrt(hh)
+		if ort[m] > 0 {
+			g = -g
+		}
+		hh -= ort[m] * g
+		ort[m] -= g
+
+		// apply the similarity transformation H = (I - u u'/h) H (I - u u'/h)
+		for j := m; j < n; j++ {
+			var f float64
+			for i := high; i >= m; i-- {
+				f += ort[i] * h[i][j]
+			}
+			f /= hh
+			for i := m; i <= high; i++ {
+				h[i][j] -= f * ort[i]
+			}
+		}
+		for i := 0; i <= high; i++ {
+			var f float64
+			for j := high; j >= m; j-- {
+				f += ort[j] * h[i][j]
+			}
+			f /= hh
+			for j := m; j <= high; j++ {
+				h[i][j] -= f * ort[j]
+			}
+		}
+		ort[m] = scale * ort[m]
+		h[m][m-1] = scale * g
+	}
+
+	// accumulate the transformations
+	v := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		v[i][i] = 1
+	}
+	for m := high - 1; m >= low+1; m-- {
+		if h[m][m-1] == 0 {
+			continue
+		}
+		for i := m + 1; i <= high; i++ {
+			ort[i] = h[i][m-1]
+		}
+		for j := m; j <= high; j++ {
+			var g float64
+			for i := m; i <= high; i++ {
+				g += ort[i] * v[i][j]
+			}
+			// double division avoids possible underflow
+			g = (g / ort[m]) / h[m][m-1]
+			for i := m; i <= high; i++ {
+				v[i][j] += g * ort[i]
+			}
+		}
+	}
+	return v
+}
+
+// hqr2 reduces an upper Hessenberg matrix to real Schur form with the shifted double QR algorithm and
+// returns the real and imaginary parts of the eigenvalues. The eigenvectors are accumulated in v.
+func hqr2(h [][]float64, v [][]float64) ([]float64, []float64, error) {
+	nn := len(h)
+	n := nn - 1
+	low, high := 0, nn-1
+	eps := math.Pow(2, -52)
+	d := make([]float64, nn)
+	e := make([]float64, nn)
+	var exshift, p, q, r, s, z, t, w, x, y float64
+
+	// compute the matrix norm
+	var norm float64
+	for i := 0; i < nn; i++ {
+		for j := int(math.Max(float64(i-1), 0)); j < nn; j++ {
+			norm += math.Abs(h[i][j])
+		}
+	}
+
+	// outer loop over the eigenvalue index
+	iter, totalIter := 0, 0
+	for n >= low {
+		// look for a single small sub-diagonal element
+		l := n
+		for l > low {
+			s = math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[l][l-1]) < eps*s {
+				break
+			}
+			l--
+		}
+
+		if l == n {
+			// one root found
+			h[n][n] += exshift
+			d[n] = h[n][n]
+			e[n] = 0
+			n--
+			iter = 0
+		} else if l == n-1 {
+			// two roots found
+			w = h[n][n-1] * h[n-1][n]
+			p = (h[n-1][n-1] - h[n][n]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			h[n][n] += exshift
+			h[n-1][n-1] += exshift
+			x = h[n][n]
+
+			if q >= 0 {
+				// real pair
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[n-1] = x + z
+				d[n] = d[n-1]
+				if z != 0 {
+					d[n] = x - w/z
+				}
+				e[n-1] = 0
+				e[n] = 0
+				x = h[n][n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p = x / s
+				q = z / s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+
+				// row modification
+				for j := n - 1; j < nn; j++ {
+					z = h[n-1][j]
+					h[n-1][j] = q*z + p*h[n][j]
+					h[n][j] = q*h[n][j] - p*z
+				}
+				// column modification
+				for i := 0; i <= n; i++ {
+					z = h[i][n-1]
+					h[i][n-1] = q*z + p*h[i][n]
+					h[i][n] = q*h[i][n] - p*z
+				}
+				// accumulate transformations
+				for i := low; i <= high; i++ {
+					z = v[i][n-1]
+					v[i][n-1] = q*z + p*v[i][n]
+					v[i][n] = q*v[i][n] - p*z
+				}
+			} else {
+				// complex pair
+				d[n-1] = x + p
+				d[n] = x + p
+				e[n-1] = z
+				e[n] = -z
+			}
+			n -= 2
+			iter = 0
+		} else {
+			// no convergence yet, form the shift
+			x = h[n][n]
+			y = 0
+			w = 0
+			if l < n {
+				y = h[n-1][n-1]
+				w = h[n][n-1] * h[n-1][n]
+			}
+
+			// Wilkinson's original ad hoc shift
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= n; i++ {
+					h[i][i] -= x
+				}
+				s = math.Abs(h[n][n-1]) + math.Abs(h[n-1][n-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's new ad hoc shift
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := low; i <= n; i++ {
+						h[i][i] -= s
+					}
+					exshift += s
+					x = 0.964
+					y = x
+					w = x
+				}
+			}
+
+			iter++
+			totalIter++
+			if totalIter > 100*nn {
+				return nil, nil, fmt.Errorf("eig: %w: the QR algorithm exceeded %d iterations", ErrNotConverged, totalIter-1)
+			}
+
+			// look for two consecutive small sub-diagonal elements
+			m := n - 2
+			for m >= l {
+				z = h[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/h[m+1][m] + h[m][m+1]
+				q = h[m+1][m+1] - z - r - s
+				r = h[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				if math.Abs(h[m][m-1])*(math.Abs(q)+math.Abs(r)) <
+					eps*(math.Abs(p)*(math.Abs(h[m-1][m-1])+math.Abs(z)+math.Abs(h[m+1][m+1]))) {
+					break
+				}
+				m--
+			}
+
+			for i := m + 2; i <= n; i++ {
+				h[i][i-2] = 0
+				if i > m+2 {
+					h[i][i-3] = 0
+				}
+			}
+
+			// double QR step involving rows l:n and columns m:n
+			for k := m; k <= n-1; k++ {
+				notLast := k != n-1
+				if k != m {
+					p = h[k][k-1]
+					q = h[k+1][k-1]
+					r = 0
+					if notLast {
+						r = h[k+2][k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s == 0 {
+					continue
+				}
+				if k != m {
+					h[k][k-1] = -s * x
+				} else if l != m {
+					h[k][k-1] = -h[k][k-1]
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+
+				// row modification
+				for j := k; j < nn; j++ {
+					p = h[k][j] + q*h[k+1][j]
+					if notLast {
+						p += r * h[k+2][j]
+						h[k+2][j] -= p * z
+					}
+					h[k][j] -= p * x
+					h[k+1][j] -= p * y
+				}
+				// column modification
+				for i := 0; i <= int(math.Min(float64(n), float64(k+3))); i++ {
+					p = x*h[i][k] + y*h[i][k+1]
+					if notLast {
+						p += z * h[i][k+2]
+						h[i][k+2] -= p * r
+					}
+					h[i][k] -= p
+					h[i][k+1] -= p * q
+				}
+				// accumulate transformations
+				for i := low; i <= high; i++ {
+					p = x*v[i][k] + y*v[i][k+1]
+					if notLast {
+						p += z * v[i][k+2]
+						v[i][k+2] -= p * r
+					}
+					v[i][k] -= p
+					v[i][k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	// back substitute to find the vectors of the upper triangular form
+	if norm == 0 {
+		return d, e, nil
+	}
+	for n = nn - 1; n >= 0; n-- {
+		p = d[n]
+		q = e[n]
+
+		if q == 0 {
+			// real vector
+			l := n
+			h[n][n] = 1
+			for i := n - 1; i >= 0; i-- {
+				w = h[i][i] - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += h[i][j] * h[j][n]
+				}
+				if e[i] < 0 {
+					z = w
+					s = r
+					continue
+				}
+				l = i
+				if e[i] == 0 {
+					if w != 0 {
+						h[i][n] = -r / w
+					} else {
+						h[i][n] = -r / (eps * norm)
+					}
+				} else {
+					// solve the real equations
+					x = h[i][i+1]
+					y = h[i+1][i]
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					h[i][n] = t
+					if math.Abs(x) > math.Abs(z) {
+						h[i+1][n] = (-r - w*t) / x
+					} else {
+						h[i+1][n] = (-s - y*t) / z
+					}
+				}
+				// overflow control
+				t = math.Abs(h[i][n])
+				if (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n] /= t
+					}
+				}
+			}
+		} else if q < 0 {
+			// complex vector, the last vector component is imaginary so the matrix is triangular
+			l := n - 1
+			if math.Abs(h[n][n-1]) > math.Abs(h[n-1][n]) {
+				h[n-1][n-1] = q / h[n][n-1]
+				h[n-1][n] = -(h[n][n] - p) / h[n][n-1]
+			} else {
+				c := complex(0, -h[n-1][n]) / complex(h[n-1][n-1]-p, q)
+				h[n-1][n-1] = real(c)
+				h[n-1][n] = imag(c)
+			}
+			h[n][n-1] = 0
+			h[n][n] = 1
+			for i := n - 2; i >= 0; i-- {
+				var ra, sa float64
+				for j := l; j <= n; j++ {
+					ra += h[i][j] * h[j][n-1]
+					sa += h[i][j] * h[j][n]
+				}
+				w = h[i][i] - p
+
+				if e[i] < 0 {
+					z = w
+					r = ra
+					s = sa
+					continue
+				}
+				l = i
+				if e[i] == 0 {
+					c := complex(-ra, -sa) / complex(w, q)
+					h[i][n-1] = real(c)
+					h[i][n] = imag(c)
+				} else {
+					// solve the complex equations
+					x = h[i][i+1]
+					y = h[i+1][i]
+					vr := (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi := (d[i] - p) * 2 * q
+					if vr == 0 && vi == 0 {
+						vr = eps * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) + math.Abs(y) + math.Abs(z))
+					}
+					c := complex(x*r-z*ra+q*sa, x*s-z*sa-q*ra) / complex(vr, vi)
+					h[i][n-1] = real(c)
+					h[i][n] = imag(c)
+					if math.Abs(x) > math.Abs(z)+math.Abs(q) {
+						h[i+1][n-1] = (-ra - w*h[i][n-1] + q*h[i][n]) / x
+						h[i+1][n] = (-sa - w*h[i][n] - q*h[i][n-1]) / x
+					} else {
+						c = complex(-r-y*h[i][n-1], -s-y*h[i][n]) / complex(z, q)
+						h[i+1][n-1] = real(c)
+						h[i+1][n] = imag(c)
+					}
+				}
+				// overflow control
+				t = math.Max(math.Abs(h[i][n-1]), math.Abs(h[i][n]))
+				if (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n-1] /= t
+						h[j][n] /= t
+					}
+				}
+			}
+		}
+	}
+
+	// back transformation to get the eigenvectors of the original matrix
+	for j := nn - 1; j >= low; j-- {
+		for i := low; i <= high; i++ {
+			z = 0
+			for k := low; k <= int(math.Min(float64(j), float64(high))); k++ {
+				z += v[i][k] * h[k][j]
+			}
+			v[i][j] = z
+		}
+	}
+	return d, e, nil
+}
+
+// Eigh returns the eigenvalues in ascending order and the orthonormal eigenvectors, as columns, of a
+// symmetric matrix like numpy.linalg.eigh, reading only its lower triangle. It uses the cyclic Jacobi
+// method, which is accurate for the small and medium sized matrices of structural models.
+func Eigh(array [][]float64) ([]float64, [][]float64, error) {
+	if err := checkSquare("eigh", array); err != nil {
+		return nil, nil, err
+	}
+	n := len(array)
+	a := Zeros(n, n)
+	v := Zeros(n, n)
+	var total float64
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			a[i][j], a[j][i] = array[i][j], array[i][j]
+			total = math.Hypot(total, array[i][j])
+		}
+		v[i][i] = 1
+	}
+
+	eps := 2.220446049250313e-16
+	for sweep := 0; ; sweep++ {
+		var off float64
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off = math.Hypot(off, a[p][q])
+			}
+		}
+		if off <= eps*total {
+			break
+		}
+		if sweep == 100 {
+			return nil, nil, fmt.Errorf("eigh: %w", ErrNotConverged)
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				// the rotation that annihilates a[p][q]
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotateColumns(a, p, q, c, s)
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				rotateColumns(v, p, q, c, s)
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return a[order[i]][order[i]] < a[order[j]][order[j]] })
+	values := make([]float64, n)
+	vectors := Zeros(n, n)
+	for k, j := range order {
+		values[k] = a[j][j]
+		for i := 0; i < n; i++ {
+			vectors[i][k] = v[i][j]
+		}
+	}
+	return values, vectors, nil
+}
+
+// Lstsq returns the least-squares solution x minimizing ||a x - b|| for an m by n matrix a, computed from
+// the SVD of a like numpy.linalg.lstsq. Singular values below rcond times the largest are treated as zero,
+// giving the minimum norm solution of rank deficient problems; rcond <= 0 selects machine precision times
+// max(m, n). The other results are the sum of squared residuals, returned only when a has full column
+// rank and m > n, the effective rank and the singular values of a.
+func Lstsq(a [][]float64, b []float64, rcond float64) ([]float64, []float64, int, []float64, error) {
+	if err := checkMatrix("lstsq", a); err != nil {
+		return nil, nil, 0, nil, err
+	}
+	m, n := len(a), len(a[0])
+	if len(b) != m {
+		return nil, nil, 0, nil, fmt.Errorf("lstsq: %w: a has %d rows and b has %d elements", ErrShapeMismatch, m, len(b))
+	}
+	if rcond <= 0 {
+		rcond = 2.220446049250313e-16 * math.Max(float64(m), float64(n))
+	}
+	u, s, vt, err := SVD(a)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	x := make([]float64, n)
+	rank := 0
+	for k := range s {
+		if s[k] <= rcond*s[0] {
+			continue
+		}
+		rank++
+		var ub float64
+		for i := 0; i < m; i++ {
+			ub += u[i][k] * b[i]
+		}
+		for j := 0; j < n; j++ {
+			x[j] += vt[k][j] * ub / s[k]
+		}
+	}
+
+	var residuals []float64
+	if rank == n && m > n {
+		var sum float64
+		for i := 0; i < m; i++ {
+			r := Dot(a[i], x) - b[i]
+			sum += r * r
+		}
+		residuals = []float64{sum}
+	}
+	return x, residuals, rank, s, nil
+}
+
+// Pinv returns the Moore-Penrose pseudo-inverse of a matrix from its SVD, treating singular values below
+// rcond times the largest as zero like numpy.linalg.pinv. rcond <= 0 selects numpy's default of 1e-15.
+func Pinv(array [][]float64, rcond float64) ([][]float64, error) {
+	if err := checkMatrix("pinv", array); err != nil {
+		return nil, err
+	}
+	if rcond <= 0 {
+		rcond = 1e-15
+	}
+	u, s, vt, err := SVD(array)
+	if err != nil {
+		return nil, err
+	}
+	m, n := len(array), len(array[0])
+	result := Zeros(n, m)
+	for k := range s {
+		if s[k] <= rcond*s[0] {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				result[i][j] += vt[k][i] * u[j][k] / s[k]
+			}
+		}
+	}
+	return result, nil
+}
+
+// MatrixRank returns the number of singular values of a matrix above the tolerance of numpy.linalg.matrix_rank,
+// the largest singular value times max(m, n) times machine precision.
+func MatrixRank(array [][]float64) (int, error) {
+	if err := checkMatrix("matrix_rank", array); err != nil {
+		return 0, err
+	}
+	_, s, _, err := SVD(array)
+	if err != nil {
+		return 0, err
+	}
+	tol := s[0] * math.Max(float64(len(array)), float64(len(array[0]))) * 2.220446049250313e-16
+	rank := 0
+	for _, value := range s {
+		if value > tol {
+			rank++
+		}
+	}
+	return rank, nil
+}
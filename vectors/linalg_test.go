@@ -0,0 +1,512 @@
+package vectors
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// identityError returns the largest deviation of a from the identity matrix
+func identityError(a [][]float64) float64 {
+	var maxErr float64
+	for i := range a {
+		for j := range a[i] {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			maxErr = math.Max(maxErr, math.Abs(a[i][j]-expected))
+		}
+	}
+	return maxErr
+}
+
+func TestInv(t *testing.T) {
+	a := [][]float64{{0, 2, 1, 3}, {4, 1, 0, -1}, {2, 2, 5, 1}, {1, -3, 2, 0}}
+	inv, err := Inv(a)
+	if err != nil {
+		t.Fatalf("Inv returned error %v", err)
+	}
+	if e := identityError(Matmul(a, inv)); e > 1e-12 {
+		t.Errorf("a * Inv(a) deviates from the identity by %v", e)
+	}
+
+	expected := [][]float64{{-2, 1}, {1.5, -0.5}}
+	inv, _ = Inv([][]float64{{1, 2}, {3, 4}})
+	for i := range expected {
+		if !AllClose(expected[i], inv[i], 1e-12) {
+			t.Errorf("Got %v, want %v", inv, expected)
+		}
+	}
+
+	if _, err := Inv([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}}); !errors.Is(err, ErrSingular) {
+		t.Errorf("Got %v, want %v", err, ErrSingular)
+	}
+	if _, err := Inv([][]float64{{1, 2}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Got %v, want %v", err, ErrShapeMismatch)
+	}
+	if _, err := Inv(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Got %v, want %v", err, ErrEmpty)
+	}
+}
+
+func TestDet(t *testing.T) {
+	for _, c := range []struct {
+		a        [][]float64
+		expected float64
+	}{
+		{[][]float64{{1, 2}, {3, 4}}, -2},
+		{[][]float64{{0, 1}, {1, 0}}, -1},
+		{[][]float64{{2, -3, 1}, {2, 0, -1}, {1, 4, 5}}, 49},
+		{[][]float64{{1, 2}, {2, 4}}, 0},
+	} {
+		output, err := Det(c.a)
+		if err != nil || math.Abs(output-c.expected) > 1e-12 {
+			t.Errorf("Det(%v) = %v, %v, want %v", c.a, output, err, c.expected)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	a := [][]float64{{3, 1}, {1, 2}}
+	expected := []float64{2, 3}
+	output, err := Solve(a, []float64{9, 8})
+	if err != nil || !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", output, err, expected)
+	}
+
+	// pivoting handles a zero in the leading position
+	a = [][]float64{{0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+	expected = []float64{1, 2, 3}
+	output, err = Solve(a, []float64{5, 4, 3})
+	if err != nil || !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", output, err, expected)
+	}
+
+	if _, err := Solve(a, []float64{1, 2}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Got %v, want %v", err, ErrShapeMismatch)
+	}
+	if _, err := Solve([][]float64{{1, 1}, {1, 1}}, []float64{1, 2}); !errors.Is(err, ErrSingular) {
+		t.Errorf("Got %v, want %v", err, ErrSingular)
+	}
+}
+
+// matricesClose reports whether two matrices agree element-wise within tol
+func matricesClose(a, b [][]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) || !AllClose(a[i], b[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLU(t *testing.T) {
+	// reference values from scipy.linalg.lu
+	p, l, u, err := LU([][]float64{{1, 2}, {3, 4}})
+	if err != nil ||
+		!matricesClose([][]float64{{0, 1}, {1, 0}}, p, 0) ||
+		!matricesClose([][]float64{{1, 0}, {1.0 / 3, 1}}, l, 1e-15) ||
+		!matricesClose([][]float64{{3, 4}, {0, 2.0 / 3}}, u, 1e-15) {
+		t.Errorf("Got %v, %v, %v, %v", p, l, u, err)
+	}
+
+	a := [][]float64{{2, 5, 8, 7}, {5, 2, 2, 8}, {7, 5, 6, 6}, {5, 4, 4, 8}}
+	p, l, u, _ = LU(a)
+	if !matricesClose(a, Matmul(p, Matmul(l, u)), 1e-12) {
+		t.Errorf("p l u = %v, want %v", Matmul(p, Matmul(l, u)), a)
+	}
+}
+
+func TestQR(t *testing.T) {
+	// reference values from numpy.linalg.qr
+	q, r, err := QR([][]float64{{1, 2}, {3, 4}})
+	expectedQ := [][]float64{{-0.31622777, -0.9486833}, {-0.9486833, 0.31622777}}
+	expectedR := [][]float64{{-3.16227766, -4.42718872}, {0, -0.63245553}}
+	if err != nil || !matricesClose(expectedQ, q, 1e-8) || !matricesClose(expectedR, r, 1e-8) {
+		t.Errorf("Got %v, %v, %v, want %v, %v", q, r, err, expectedQ, expectedR)
+	}
+
+	// numpy.linalg.qr gives the negative diagonal of LAPACK's Householder reflections, here the exact
+	// fractions of Q = [[-0.85714286 0.39428571 0.33142857] [-0.42857143 -0.90285714 -0.03428571]
+	// [0.28571429 -0.17142857 0.94285714]]
+	q, r, err = QR([][]float64{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}})
+	expectedQ = [][]float64{
+		{-6.0 / 7, 69.0 / 175, 58.0 / 175},
+		{-3.0 / 7, -158.0 / 175, -6.0 / 175},
+		{2.0 / 7, -30.0 / 175, 165.0 / 175},
+	}
+	expectedR = [][]float64{{-14, -21, 14}, {0, -175, 70}, {0, 0, -35}}
+	if err != nil || !matricesClose(expectedQ, q, 1e-12) || !matricesClose(expectedR, r, 1e-12) {
+		t.Errorf("Got %v, %v, %v, want %v, %v", q, r, err, expectedQ, expectedR)
+	}
+
+	for _, a := range [][][]float64{
+		{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}, {1, 1, 1}},
+		{{1, 2, 3}, {4, 5, 6}},
+	} {
+		q, r, _ := QR(a)
+		if !matricesClose(a, Matmul(q, r), 1e-12) {
+			t.Errorf("q r = %v, want %v", Matmul(q, r), a)
+		}
+		if e := identityError(Matmul(Transpose(q), q)); e > 1e-12 {
+			t.Errorf("q^T q deviates from the identity by %v", e)
+		}
+		for i := range r {
+			for j := 0; j < i; j++ {
+				if r[i][j] != 0 {
+					t.Errorf("r is not upper triangular: %v", r)
+				}
+			}
+		}
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	l, err := Cholesky([][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}})
+	expected := [][]float64{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}
+	if err != nil || !matricesClose(expected, l, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", l, err, expected)
+	}
+	if _, err := Cholesky([][]float64{{1, 2}, {2, 1}}); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("Got %v, want %v", err, ErrNotPositiveDefinite)
+	}
+}
+
+// closeUpToSign reports whether b equals a or -a within tol, eigenvectors and singular vectors being
+// defined up to their sign
+func closeUpToSign(a, b []float64, tol float64) bool {
+	return AllClose(a, b, tol) || AllClose(a, MultiplyBy(b, -1), tol)
+}
+
+// column returns column j of a matrix
+func column(a [][]float64, j int) []float64 {
+	result := make([]float64, len(a))
+	for i := range a {
+		result[i] = a[i][j]
+	}
+	return result
+}
+
+func TestSVD(t *testing.T) {
+	// reference singular values from numpy.linalg.svd
+	_, s, _, err := SVD([][]float64{{1, 2}, {3, 4}})
+	if err != nil || !AllClose([]float64{5.4649857, 0.36596619}, s, 1e-7) {
+		t.Errorf("Got %v, %v", s, err)
+	}
+
+	for _, a := range [][][]float64{
+		{{1, 0, 0, 0, 2}, {0, 0, 3, 0, 0}, {0, 0, 0, 0, 0}, {0, 2, 0, 0, 0}},
+		{{2, 4}, {1, 3}, {0, 0}, {0, 0}},
+		{{1, 1}, {1, 1}, {1, 1}},
+		{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}},
+		{{1, 2, 3}, {2, 4, 6}, {3, 6, 9}, {4, 8, 12}},
+	} {
+		u, s, vt, err := SVD(a)
+		if err != nil {
+			t.Fatalf("SVD(%v) returned error %v", a, err)
+		}
+		usvt := Zeros(len(a), len(a[0]))
+		for i := range usvt {
+			for j := range usvt[i] {
+				for k := range s {
+					usvt[i][j] += u[i][k] * s[k] * vt[k][j]
+				}
+			}
+		}
+		if !matricesClose(a, usvt, 1e-12) {
+			t.Errorf("u s vt = %v, want %v", usvt, a)
+		}
+		if identityError(Matmul(Transpose(u), u)) > 1e-12 || identityError(Matmul(vt, Transpose(vt))) > 1e-12 {
+			t.Errorf("SVD(%v) factors are not orthonormal: %v, %v", a, u, vt)
+		}
+		for k := 1; k < len(s); k++ {
+			if s[k] > s[k-1] {
+				t.Errorf("singular values are not sorted: %v", s)
+			}
+		}
+	}
+	// the exact factors of a 2x3 matrix, which numpy.linalg.svd(a, full_matrices=False) returns up to the
+	// sign of each pair of singular vectors: u = [[-0.70710678 -0.70710678] [-0.70710678 0.70710678]],
+	// s = [5 3] and vt = [[-0.70710678 -0.70710678 0] [-0.23570226 0.23570226 -0.94280904]]
+	u, s, vt, err := SVD([][]float64{{3, 2, 2}, {2, 3, -2}})
+	expectedU := [][]float64{{math.Sqrt(0.5), math.Sqrt(0.5)}, {math.Sqrt(0.5), -math.Sqrt(0.5)}}
+	expectedVt := [][]float64{
+		{math.Sqrt(0.5), math.Sqrt(0.5), 0},
+		{1 / math.Sqrt(18), -1 / math.Sqrt(18), 4 / math.Sqrt(18)},
+	}
+	if err != nil || !AllClose([]float64{5, 3}, s, 1e-12) || len(vt) != 2 {
+		t.Fatalf("Got %v, %v, %v, want s = [5 3] and a 2x3 vt", s, vt, err)
+	}
+	for k := range expectedVt {
+		if !closeUpToSign(column(expectedU, k), column(u, k), 1e-12) {
+			t.Errorf("left singular vector %d = %v, want %v", k, column(u, k), column(expectedU, k))
+		}
+		if !closeUpToSign(expectedVt[k], vt[k], 1e-12) {
+			t.Errorf("right singular vector %d = %v, want %v", k, vt[k], expectedVt[k])
+		}
+	}
+
+	_, s, _, _ = SVD([][]float64{{1, 0, 0, 0, 2}, {0, 0, 3, 0, 0}, {0, 0, 0, 0, 0}, {0, 2, 0, 0, 0}})
+	if !AllClose([]float64{3, math.Sqrt(5), 2, 0}, s, 1e-12) {
+		t.Errorf("Got %v, want [3 sqrt(5) 2 0]", s)
+	}
+
+	// the null space of a rank deficient matrix holds only rounding noise, which gives exact zero singular
+	// values
+	_, s, _, err = SVD([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}})
+	if err != nil || len(s) != 3 || s[1] == 0 || s[2] != 0 {
+		t.Errorf("Got %v, %v, want two nonzero singular values and a zero", s, err)
+	}
+	_, s, _, err = SVD([][]float64{{1, 2, 3}, {2, 4, 6}, {3, 6, 9}, {4, 8, 12}})
+	if err != nil || !AllClose([]float64{math.Sqrt(30 * 14), 0, 0}, s, 1e-12) || s[1] != 0 || s[2] != 0 {
+		t.Errorf("Got %v, %v, want [sqrt(420) 0 0]", s, err)
+	}
+}
+
+func TestEig(t *testing.T) {
+	a := [][]float64{{1, 2}, {3, 4}}
+	values, vectors, err := Eig(a)
+	if err != nil {
+		t.Fatalf("Eig returned error %v", err)
+	}
+	// reference values from numpy.linalg.eig, the vectors are defined up to their sign
+	expected := map[float64][]float64{
+		-0.37228132: {-0.82456484, 0.56576746},
+		5.37228132:  {-0.41597356, -0.90937671},
+	}
+	for j, value := range values {
+		found := false
+		for ev, vector := range expected {
+			if math.Abs(real(value)-ev) < 1e-8 && imag(value) == 0 {
+				found = true
+				column := []float64{real(vectors[0][j]), real(vectors[1][j])}
+				if !AllClose(vector, column, 1e-8) && !AllClose(vector, MultiplyBy(column, -1), 1e-8) {
+					t.Errorf("eigenvector of %v = %v, want %v", value, column, vector)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("unexpected eigenvalue %v", value)
+		}
+	}
+
+	// an upper triangular matrix keeps its diagonal as eigenvalues, with the unit eigenvectors e1,
+	// [2 3 0]/sqrt(13) and [16 25 10]/sqrt(981) that numpy.linalg.eig gives as [1 0 0],
+	// [0.5547002 0.83205029 0] and [0.51084069 0.79818857 0.31927543]
+	a = [][]float64{{1, 2, 3}, {0, 4, 5}, {0, 0, 6}}
+	values, vectors, err = Eig(a)
+	expectedVectors := map[float64][]float64{
+		1: {1, 0, 0},
+		4: MultiplyBy([]float64{2, 3, 0}, 1/math.Sqrt(13)),
+		6: MultiplyBy([]float64{16, 25, 10}, 1/math.Sqrt(981)),
+	}
+	if err != nil || len(values) != 3 {
+		t.Fatalf("Got %v, %v", values, err)
+	}
+	for j, value := range values {
+		vector, ok := expectedVectors[math.Round(real(value))]
+		got := []float64{real(vectors[0][j]), real(vectors[1][j]), real(vectors[2][j])}
+		if !ok || math.Abs(real(value)-math.Round(real(value))) > 1e-12 || imag(value) != 0 || !closeUpToSign(vector, got, 1e-12) {
+			t.Errorf("eigenpair %v, %v, want an eigenvalue in {1 4 6} with vector %v", value, got, vector)
+		}
+	}
+
+	// a rotation has the eigenvalues +i and -i with eigenvectors +-[1, -+i] / sqrt(2)
+	values, vectors, _ = Eig([][]float64{{0, -1}, {1, 0}})
+	for j, value := range values {
+		if math.Abs(math.Abs(imag(value))-1) > 1e-12 || real(value) != 0 {
+			t.Errorf("unexpected eigenvalue %v", value)
+		}
+		residual := vectors[1][j] - complex(0, -imag(value))*vectors[0][j]
+		if math.Abs(math.Abs(real(vectors[0][j]))-math.Sqrt(0.5)) > 1e-12 || imag(vectors[0][j]) != 0 || cmplxAbs(residual) > 1e-12 {
+			t.Errorf("eigenvector of %v = [%v %v]", value, vectors[0][j], vectors[1][j])
+		}
+	}
+}
+
+func cmplxAbs(c complex128) float64 {
+	return math.Hypot(real(c), imag(c))
+}
+
+func TestEigenNonsymmetric(t *testing.T) {
+	// checkPairs verifies a v = lambda v for every eigenpair, relative to the norm of a
+	checkPairs := func(a [][]float64, values []complex128, vectors [][]complex128) {
+		var norm float64
+		for _, row := range a {
+			for _, v := range row {
+				norm = math.Max(norm, math.Abs(v))
+			}
+		}
+		for j, value := range values {
+			for i, row := range a {
+				var av complex128
+				for k, v := range row {
+					av += complex(v, 0) * vectors[k][j]
+				}
+				if cmplxAbs(av-value*vectors[i][j]) > 1e-12*norm {
+					t.Errorf("eigenpair %d of %v does not satisfy a v = lambda v", j, a)
+					break
+				}
+			}
+		}
+	}
+	sortValues := func(values []complex128) []float64 {
+		sorted := Real(values)
+		sort.Float64s(sorted)
+		return sorted
+	}
+
+	// balancing recovers the eigenvalues 2 - sqrt(3), 2, 2 + sqrt(3) of a badly scaled tridiagonal matrix
+	a := [][]float64{{1, 1e6, 0}, {1e-6, 2, 1e6}, {0, 1e-6, 3}}
+	values, vectors, err := eigenNonsymmetric(a)
+	if err != nil {
+		t.Fatalf("eigenNonsymmetric returned error %v", err)
+	}
+	if output := sortValues(values); !AllClose([]float64{2 - math.Sqrt(3), 2, 2 + math.Sqrt(3)}, output, 1e-12) {
+		t.Errorf("Got %v, want [2-sqrt(3) 2 2+sqrt(3)]", output)
+	}
+	checkPairs(a, values, vectors)
+
+	// the companion matrix of (x - 1)(x + 3)(x^2 + 1)(x^2 - 2x + 5) has three complex conjugate pairs
+	a = [][]float64{
+		{0, 1, -16, 17, -16, 15},
+		{1, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 1, 0},
+	}
+	values, vectors, _ = eigenNonsymmetric(a)
+	expected := []complex128{1, -3, complex(0, 1), complex(0, -1), complex(1, 2), complex(1, -2)}
+	for _, want := range expected {
+		found := false
+		for _, value := range values {
+			if cmplxAbs(value-want) < 1e-10 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Got %v, want %v among the eigenvalues", values, want)
+		}
+	}
+	checkPairs(a, values, vectors)
+
+	// a defective Jordan block has a repeated eigenvalue
+	values, _, _ = eigenNonsymmetric([][]float64{{2, 1}, {0, 2}})
+	if !reflect.DeepEqual([]complex128{2, 2}, values) {
+		t.Errorf("Got %v, want [2 2]", values)
+	}
+
+	a = [][]float64{{4, -2, 1, 3}, {1, 0, 7, -1}, {-3, 5, 2, 2}, {6, 1, -4, 1}}
+	values, vectors, _ = eigenNonsymmetric(a)
+	checkPairs(a, values, vectors)
+}
+
+func TestEigh(t *testing.T) {
+	a := [][]float64{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}}
+	values, vectors, err := Eigh(a)
+	expected := []float64{2 - math.Sqrt2, 2, 2 + math.Sqrt2}
+	if err != nil || !AllClose(expected, values, 1e-12) {
+		t.Errorf("Got %v, %v, want %v", values, err, expected)
+	}
+	if e := identityError(Matmul(Transpose(vectors), vectors)); e > 1e-12 {
+		t.Errorf("eigenvectors deviate from orthonormality by %v", e)
+	}
+	// the exact eigenvectors, which numpy.linalg.eigh returns up to their sign as the columns of
+	// [[-0.5 -0.70710678 0.5] [-0.70710678 0 -0.70710678] [-0.5 0.70710678 0.5]]
+	expectedVectors := [][]float64{
+		{0.5, math.Sqrt(0.5), 0.5},
+		{-math.Sqrt(0.5), 0, math.Sqrt(0.5)},
+		{0.5, -math.Sqrt(0.5), 0.5},
+	}
+	for j := range expectedVectors {
+		if !closeUpToSign(expectedVectors[j], column(vectors, j), 1e-12) {
+			t.Errorf("eigenvector %d = %v, want %v", j, column(vectors, j), expectedVectors[j])
+		}
+	}
+	av := Matmul(a, vectors)
+	for j := range values {
+		for i := range a {
+			if math.Abs(av[i][j]-values[j]*vectors[i][j]) > 1e-12 {
+				t.Errorf("a v != lambda v for eigenvalue %v", values[j])
+			}
+		}
+	}
+
+	// only the lower triangle is read
+	values, _, _ = Eigh([][]float64{{1, 100}, {2, 1}})
+	if !AllClose([]float64{-1, 3}, values, 1e-12) {
+		t.Errorf("Got %v, want [-1 3]", values)
+	}
+}
+
+func TestLstsq(t *testing.T) {
+	// the numpy.linalg.lstsq example fitting y = m x + c
+	a := [][]float64{{0, 1}, {1, 1}, {2, 1}, {3, 1}}
+	b := []float64{-1, 0.2, 0.9, 2.1}
+	x, residuals, rank, s, err := Lstsq(a, b, 0)
+	if err != nil || !AllClose([]float64{1, -0.95}, x, 1e-12) || rank != 2 {
+		t.Errorf("Got %v, %v, %v, want [1 -0.95] with rank 2", x, rank, err)
+	}
+	if len(residuals) != 1 || math.Abs(residuals[0]-0.05) > 1e-12 {
+		t.Errorf("Got residuals %v, want [0.05]", residuals)
+	}
+	if !AllClose([]float64{4.10003045, 1.09075677}, s, 1e-8) {
+		t.Errorf("Got singular values %v", s)
+	}
+
+	// a rank deficient system gets the minimum norm solution and no residuals
+	x, residuals, rank, _, _ = Lstsq([][]float64{{1, 1}, {1, 1}}, []float64{2, 2}, 0)
+	if !AllClose([]float64{1, 1}, x, 1e-12) || rank != 1 || residuals != nil {
+		t.Errorf("Got %v, %v, %v, want [1 1] with rank 1", x, residuals, rank)
+	}
+
+	if _, _, _, _, err := Lstsq(a, []float64{1}, 0); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Got %v, want %v", err, ErrShapeMismatch)
+	}
+}
+
+func TestPinv(t *testing.T) {
+	a := [][]float64{{1, 2}, {3, 4}, {5, 6}}
+	pinv, err := Pinv(a, 0)
+	if err != nil {
+		t.Fatalf("Pinv returned error %v", err)
+	}
+	// the Moore-Penrose conditions a p a = a and p a p = p
+	if !matricesClose(a, Matmul(a, Matmul(pinv, a)), 1e-12) || !matricesClose(pinv, Matmul(pinv, Matmul(a, pinv)), 1e-12) {
+		t.Errorf("Pinv(%v) = %v does not satisfy the Moore-Penrose conditions", a, pinv)
+	}
+	expected := [][]float64{{-4.0 / 3, -1.0 / 3, 2.0 / 3}, {13.0 / 12, 1.0 / 3, -5.0 / 12}}
+	if !matricesClose(expected, pinv, 1e-12) {
+		t.Errorf("Got %v, want %v", pinv, expected)
+	}
+
+	// small singular values are cut off by rcond
+	pinv, _ = Pinv([][]float64{{1, 0}, {0, 1e-10}}, 1e-5)
+	if !matricesClose([][]float64{{1, 0}, {0, 0}}, pinv, 0) {
+		t.Errorf("Got %v, want [[1 0] [0 0]]", pinv)
+	}
+}
+
+func TestMatrixRank(t *testing.T) {
+	for _, c := range []struct {
+		a        [][]float64
+		expected int
+	}{
+		{[][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}, 3},
+		{[][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}}, 2},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 2},
+		{[][]float64{{0, 0}, {0, 0}}, 0},
+	} {
+		output, err := MatrixRank(c.a)
+		if err != nil || output != c.expected {
+			t.Errorf("MatrixRank(%v) = %v, %v, want %v", c.a, output, err, c.expected)
+		}
+	}
+}
@@ -1,63 +1,56 @@
 package vectors
 
 import (
+	"fmt"
 	"math"
 )
 
-// MultiplyBy multiplies elements of a slice with a number or slice of numbers
-func MultiplyBy[T Float](array []float64, factor T) []float64 {
+// MultiplyBy multiplies elements of a slice with a number
+func MultiplyBy[S, T Number](elements []S, factor T) []float64 {
+	array := toFloat64s(elements)
 	var multipliedArray []float64
-	if IsVector(factor) {
-		factor := AsSlice(factor)
-		for i := range array {
-			multipliedArray = append(multipliedArray, array[i]*factor[i])
-		}
-	} else {
-		for i := range array {
-			multipliedArray = append(multipliedArray, array[i]*asFloat64(factor))
-		}
+	for i := range array {
+		multipliedArray = append(multipliedArray, array[i]*float64(factor))
 	}
 
 	return multipliedArray
 }
 
-// Pow take power of a slice of numbers by a number or slice of numbers
-func Pow[T Float](base []float64, factor T) []float64 {
+// MultiplyByElems multiplies elements of two slices with each other, broadcasting a single element
+func MultiplyByElems[S, T Number](elements []S, factors []T) []float64 {
+	return broadcastSlices(toFloat64s(elements), toFloat64s(factors), func(a, b float64) float64 { return a * b })
+}
+
+// Pow take power of a slice of numbers by a number
+func Pow[S, T Number](elements []S, factor T) []float64 {
+	base := toFloat64s(elements)
 	var powArray []float64
-	if IsVector(factor) {
-		factor := AsSlice(factor)
-		for i := range base {
-			powArray = append(powArray, math.Pow(base[i], factor[i]))
-		}
-	} else {
-		for i := range base {
-			powArray = append(powArray, math.Pow(base[i], asFloat64(factor)))
-		}
+	for i := range base {
+		powArray = append(powArray, math.Pow(base[i], float64(factor)))
 	}
 
 	return powArray
 }
 
-// PowOf take power of a number or slice of numbers by slice of numbers
-func PowOf[T Float](base T, factor []float64) []float64 {
+// PowElems take power of elements of a slice by the elements of another, broadcasting a single element
+func PowElems[S, T Number](elements []S, factors []T) []float64 {
+	return broadcastSlices(toFloat64s(elements), toFloat64s(factors), math.Pow)
+}
+
+// PowOf take power of a number by slice of numbers
+func PowOf[T, S Number](base T, exponents []S) []float64 {
+	factor := toFloat64s(exponents)
 	var powArray []float64
-	if IsVector(base) {
-		base := AsSlice(base)
-		for i := range base {
-			powArray = append(powArray, math.Pow(base[i], factor[i]))
-		}
-	} else {
-		for i := range factor {
-			powArray = append(powArray, math.Pow(asFloat64(base), factor[i]))
-		}
+	for i := range factor {
+		powArray = append(powArray, math.Pow(float64(base), factor[i]))
 	}
 
 	return powArray
 }
 
 // Sum sums a slice of numbers
-func Sum(array []float64) float64 {
-	var sum float64
+func Sum[T Number](array []T) T {
+	var sum T
 
 	for _, value := range array {
 		sum = sum + value
@@ -67,8 +60,20 @@ func Sum(array []float64) float64 {
 }
 
 // Cumsum returns cumulative sums of a slice
-func Cumsum(array []float64) []float64 {
-	var cumsum []float64
+func Cumsum[T Number](array []T) []T {
+	cumsum, err := TryCumsum(array)
+	if err != nil {
+		panic(err)
+	}
+	return cumsum
+}
+
+// TryCumsum is Cumsum returning ErrEmpty instead of panicking
+func TryCumsum[T Number](array []T) ([]T, error) {
+	if len(array) == 0 {
+		return nil, fmt.Errorf("%w: cumsum of an empty slice", ErrEmpty)
+	}
+	var cumsum []T
 
 	cumsum = append(cumsum, array[0])
 
@@ -76,14 +81,14 @@ func Cumsum(array []float64) []float64 {
 		cumsum = append(cumsum, array[i]+cumsum[i-1])
 	}
 
-	return cumsum
+	return cumsum, nil
 }
 
 // Round rounds a slice of numbers to a given decimal
-func Round(array []float64, decimals int) []float64 {
-	var roundedArray []float64
+func Round[T Number](array []T, decimals int) []T {
+	var roundedArray []T
 	for i := range array {
-		roundedNum := RoundFloat(array[i], decimals)
+		roundedNum := T(RoundFloat(float64(array[i]), decimals))
 		roundedArray = append(roundedArray, roundedNum)
 	}
 
@@ -91,8 +96,8 @@ func Round(array []float64, decimals int) []float64 {
 }
 
 // Round2D rounds a 2D array of numbers to a given decimal
-func Round2D(array [][]float64, decimals int) [][]float64 {
-	var roundedArray [][]float64
+func Round2D[T Number](array [][]T, decimals int) [][]T {
+	var roundedArray [][]T
 	for _, row := range array {
 		roundedRow := Round(row, decimals)
 		roundedArray = append(roundedArray, roundedRow)
@@ -106,7 +111,19 @@ func RoundFloat(num float64, decimals int) float64 {
 }
 
 // Max returns the maximum value and its index of a slice of numbers
-func Max(array []float64) (float64, int) {
+func Max[T Number](array []T) (T, int) {
+	max, index, err := TryMax(array)
+	if err != nil {
+		panic(err)
+	}
+	return max, index
+}
+
+// TryMax is Max returning ErrEmpty instead of panicking
+func TryMax[T Number](array []T) (T, int, error) {
+	if len(array) == 0 {
+		return 0, 0, fmt.Errorf("%w: max of an empty slice", ErrEmpty)
+	}
 	max := array[0]
 	index := 0
 	for i, value := range array {
@@ -116,12 +133,12 @@ func Max(array []float64) (float64, int) {
 		}
 	}
 
-	return max, index
+	return max, index, nil
 }
 
 // Max2D returns the maximum of rows or columns of a matrix
-func Max2D(array [][]float64, axis int) []float64 {
-	var max []float64
+func Max2D[T Number](array [][]T, axis int) []T {
+	var max []T
 	if axis == 1 {
 		for i := range array {
 			maxVal, _ := Max(array[i])
@@ -137,7 +154,19 @@ func Max2D(array [][]float64, axis int) []float64 {
 }
 
 // Min returns the maximum value and its index of a slice of numbers
-func Min(array []float64) (float64, int) {
+func Min[T Number](array []T) (T, int) {
+	min, index, err := TryMin(array)
+	if err != nil {
+		panic(err)
+	}
+	return min, index
+}
+
+// TryMin is Min returning ErrEmpty instead of panicking
+func TryMin[T Number](array []T) (T, int, error) {
+	if len(array) == 0 {
+		return 0, 0, fmt.Errorf("%w: min of an empty slice", ErrEmpty)
+	}
 	min := array[0]
 	index := 0
 
@@ -148,30 +177,33 @@ func Min(array []float64) (float64, int) {
 		}
 	}
 
-	return min, index
+	return min, index, nil
 }
 
 // Mean returns the mean of a slice of numbers
-func Mean(array []float64) float64 {
+func Mean[T Number](array []T) float64 {
 	if len(array) == 0 {
 		return 0
 	}
-	return Sum(array) / float64(len(array))
+	return Sum(ConvertFloat(array)) / float64(len(array))
 }
 
 // Abs returns the absolute value of a slice of numbers
-func Abs(array []float64) []float64 {
-	var absArray []float64
-	for i := range array {
-		absArray = append(absArray, math.Abs(array[i]))
+func Abs[T Number](array []T) []T {
+	var absArray []T
+	for _, value := range array {
+		if value < 0 {
+			value = -value
+		}
+		absArray = append(absArray, value)
 	}
 
 	return absArray
 }
 
 // Abs2D returns the absolute value of a slice of numbers
-func Abs2D(array [][]float64) [][]float64 {
-	var absArray [][]float64
+func Abs2D[T Number](array [][]T) [][]T {
+	var absArray [][]T
 	for i := range array {
 		absArray = append(absArray, Abs(array[i]))
 	}
@@ -179,37 +211,35 @@ func Abs2D(array [][]float64) [][]float64 {
 	return absArray
 }
 
-// SumWith sums elements of two slices with each other
-func SumWith[T Float](array1 []float64, factor T) []float64 {
+// SumWith adds a number to the elements of a slice
+func SumWith[S, T Number](elements []S, factor T) []float64 {
+	array1 := toFloat64s(elements)
 	var array []float64
-	if IsVector(factor) {
-		array2 := AsSlice(factor)
-		if len(array1) != len(array2) {
-			panic("arrays must be of the same length")
-		}
-		for i := range array1 {
-			array = append(array, array1[i]+array2[i])
-		}
-	} else {
-		for i := range array1 {
-			array = append(array, array1[i]+asFloat64(factor))
-		}
+	for i := range array1 {
+		array = append(array, array1[i]+float64(factor))
 	}
 	return array
 }
 
+// SumWithElems sums elements of two slices with each other, broadcasting a single element
+func SumWithElems[S, T Number](elements []S, factors []T) []float64 {
+	return broadcastSlices(toFloat64s(elements), toFloat64s(factors), func(a, b float64) float64 { return a + b })
+}
+
 // DividedBy divides elements of two slices with each other
-func DividedBy(array1 []float64, array2 []float64) []float64 {
-	var array []float64
-	if len(array1) != len(array2) {
-		panic("arrays must be of the same length")
-	}
-	for i := range array1 {
-		array = append(array, array1[i]/array2[i])
+func DividedBy[S, T Number](array1 []S, array2 []T) []float64 {
+	array, err := TryDividedBy(array1, array2)
+	if err != nil {
+		panic(err)
 	}
 	return array
 }
 
+// TryDividedBy is DividedBy returning a *ShapeError, which matches ErrShapeMismatch, instead of panicking
+func TryDividedBy[S, T Number](array1 []S, array2 []T) ([]float64, error) {
+	return tryBroadcastSlices(toFloat64s(array1), toFloat64s(array2), func(a, b float64) float64 { return a / b })
+}
+
 // Geomspace returns a slice of numbers spaced evenly on a geometric progression
 func Geomspace(start float64, end float64, length float64) []float64 {
 	array := []float64{start}
@@ -222,15 +252,24 @@ func Geomspace(start float64, end float64, length float64) []float64 {
 }
 
 // Dot returns the dot product of two slices
-func Dot(array1 []float64, array2 []float64) float64 {
+func Dot[T Number](array1 []T, array2 []T) T {
+	sum, err := TryDot(array1, array2)
+	if err != nil {
+		panic(err)
+	}
+	return sum
+}
+
+// TryDot is Dot returning ErrShapeMismatch instead of panicking
+func TryDot[T Number](array1 []T, array2 []T) (T, error) {
 	if len(array1) != len(array2) {
-		panic("arrays must be of the same length")
+		return 0, fmt.Errorf("%w: dot product of slices of lengths %d and %d", ErrShapeMismatch, len(array1), len(array2))
 	}
-	var sum float64
+	var sum T
 	for i := range array1 {
 		sum += array1[i] * array2[i]
 	}
-	return sum
+	return sum, nil
 }
 
 // Angle returns the angle of the complex argument
@@ -291,12 +330,12 @@ func LogSpace(start float64, end float64, length float64) []float64 {
 }
 
 // AllClose returns true if all elements of two slices are within a tolerance of each other
-func AllClose(array1 []float64, array2 []float64, tol float64) bool {
+func AllClose[T Number](array1 []T, array2 []T, tol float64) bool {
 	if len(array1) != len(array2) {
 		return false
 	}
 	for i := range array1 {
-		if math.Abs(array1[i]-array2[i]) > tol {
+		if math.Abs(float64(array1[i])-float64(array2[i])) > tol {
 			return false
 		}
 	}
@@ -327,28 +366,24 @@ func RealIfClose(array []complex128) any {
 
 }
 
-// FloorDivide returns the floor of the quotient of two arrays
-func FloorDivide[T Float](numerator []float64, denominator T) []float64 {
+// FloorDivide returns the floor of the quotient of an array and a number
+func FloorDivide[S, T Number](elements []S, denominator T) []float64 {
+	numerator := toFloat64s(elements)
 	var result []float64
-	if IsVector(denominator) {
-		denominator := AsSlice(denominator)
-		if len(numerator) != len(denominator) {
-			panic("numerator and denominator must have the same length")
-		}
-		for i := range numerator {
-			result = append(result, math.Floor(numerator[i]/denominator[i]))
-		}
-	} else {
-		for i := range numerator {
-			result = append(result, math.Floor(numerator[i]/asFloat64(denominator)))
-		}
+	for i := range numerator {
+		result = append(result, math.Floor(numerator[i]/float64(denominator)))
 	}
 	return result
 }
 
+// FloorDivideElems returns the floor of the element-wise quotient of two arrays, broadcasting a single element
+func FloorDivideElems[S, T Number](elements []S, denominators []T) []float64 {
+	return broadcastSlices(toFloat64s(elements), toFloat64s(denominators), func(a, b float64) float64 { return math.Floor(a / b) })
+}
+
 // Diff returns the n-th differences of the given array.
-func Diff(array []float64) []float64 {
-	var result []float64
+func Diff[T Number](array []T) []T {
+	var result []T
 	for i := 1; i < len(array); i++ {
 		result = append(result, array[i]-array[i-1])
 	}
@@ -356,10 +391,10 @@ func Diff(array []float64) []float64 {
 }
 
 // IsFinite returns a bool array, where true if input element is finite.
-func IsFinite(array []float64) []bool {
+func IsFinite[T Number](array []T) []bool {
 	var result []bool
 	for _, elem := range array {
-		if math.IsInf(elem, 0) {
+		if math.IsInf(float64(elem), 0) {
 			result = append(result, false)
 		} else {
 			result = append(result, true)
@@ -369,30 +404,59 @@ func IsFinite(array []float64) []bool {
 }
 
 // Matmul returns the matrix product of two arrays
-func Matmul(a, b [][]float64) [][]float64 {
-	var result [][]float64
-	transposeB := Transpose(b)
-	if !CheckConsistency(a) || !CheckConsistency(transposeB) {
-		panic("all rows or columns must have the same length")
+func Matmul[T Number](a, b [][]T) [][]T {
+	result, err := TryMatmul(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryMatmul is Matmul returning ErrEmpty or ErrShapeMismatch instead of panicking
+func TryMatmul[T Number](a, b [][]T) ([][]T, error) {
+	var result [][]T
+	if len(a) == 0 || len(b) == 0 || len(b[0]) == 0 {
+		return nil, fmt.Errorf("%w: matrix product with an empty matrix", ErrEmpty)
 	}
-	if len(a[0]) != len(Transpose(b)[0]) {
-		panic("These matrixes can't be multiplied")
+	if !CheckConsistency(a) || !CheckConsistency(b) {
+		return nil, fmt.Errorf("%w: all rows or columns must have the same length", ErrShapeMismatch)
 	}
+	if len(a[0]) != len(b) {
+		return nil, fmt.Errorf("%w: matrices with %d columns and %d rows can't be multiplied", ErrShapeMismatch, len(a[0]), len(b))
+	}
+	transposeB := Transpose(b)
 	for _, row := range a {
-		var newRow []float64
+		var newRow []T
 		for _, col := range transposeB {
 			newRow = append(newRow, Dot(row, col))
 		}
 		result = append(result, newRow)
 	}
-	return result
+	return result, nil
 }
 
 // Interp returns an array of linearly interpolated values.
-func Interp(x, xp, fp []float64) []float64 {
+func Interp[T Number](x, xp, fp []T) []float64 {
+	result, err := TryInterp(x, xp, fp)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryInterp is Interp returning ErrShapeMismatch or ErrEmpty instead of panicking
+func TryInterp[T Number](x, xp, fp []T) ([]float64, error) {
+	return tryInterp(toFloat64s(x), toFloat64s(xp), toFloat64s(fp))
+}
+
+// tryInterp is TryInterp on float64 values
+func tryInterp(x, xp, fp []float64) ([]float64, error) {
 	var result []float64
 	if len(xp) != len(fp) {
-		panic("xp and fp must have the same length")
+		return nil, fmt.Errorf("%w: xp and fp must have the same length", ErrShapeMismatch)
+	}
+	if len(xp) == 0 {
+		return nil, fmt.Errorf("%w: interpolation without sample points", ErrEmpty)
 	}
 	for _, xi := range x {
 		if xi < xp[0] {
@@ -409,66 +473,45 @@ func Interp(x, xp, fp []float64) []float64 {
 			}
 		}
 	}
-	return result
+	return result, nil
 }
 
-// Inverse returns the inverse of a 2X2 matrix
+// Inverse returns the inverse of a square matrix
 func Inverse(array [][]float64) [][]float64 {
-	if len(array[0]) != 2 || len(array) != 2 {
-		panic("array must be a 2X2 matrix")
+	result, err := TryInverse(array)
+	if err != nil {
+		panic(err)
 	}
-	coef := 1 / (array[0][0]*array[1][1] - array[0][1]*array[1][0])
-	result := [][]float64{{array[1][1] * coef, -array[0][1] * coef}, {-array[1][0] * coef, array[0][0] * coef}}
 	return result
 }
 
-// Polyfit returns the coefficients of a polynomial of degree 1 that fits the data
-func Polyfit(x, y []float64) (float64, float64) {
-	// y = ax +b
-	var a float64
-	var b float64
-	if len(x) != len(y) {
-		panic("x and y must have the same length")
-	}
-	sumX := Sum(x)
-	sumY := Sum(y)
-	sumX2 := Sum(Pow(x, 2))
-	XY := MultiplyBy(x, y)
-	sumXY := Sum(XY)
-
-	A := [][]float64{{sumX, float64(len(x))}, {sumX2, sumX}}
-	B := [][]float64{{sumY}, {sumXY}}
-	coeff := Matmul(Inverse(A), B)
-	a = coeff[0][0]
-	b = coeff[1][0]
-	return a, b
+// TryInverse is Inverse returning ErrEmpty, ErrShapeMismatch or ErrSingular instead of panicking
+func TryInverse(array [][]float64) ([][]float64, error) {
+	return Inv(array)
 }
 
-// Mod returns the element-wise remainder of division
-func Mod[T Float](x []float64, y T) []float64 {
+// Mod returns the remainder of the division of the elements of an array by a number
+func Mod[S, T Number](elements []S, y T) []float64 {
+	x := toFloat64s(elements)
 	var result []float64
-	if IsVector(y) {
-		y := AsSlice(y)
-		if len(x) != len(y) {
-			panic("x and y must have the same length")
-		}
-		for i := range x {
-			result = append(result, math.Mod(x[i], y[i]))
-		}
-	} else {
-		for i := range x {
-			result = append(result, math.Mod(x[i], asFloat64(y)))
-		}
+	for i := range x {
+		result = append(result, math.Mod(x[i], float64(y)))
 	}
 
 	return result
 }
 
+// ModElems returns the element-wise remainder of division, broadcasting a single element
+func ModElems[S, T Number](elements []S, y []T) []float64 {
+	return broadcastSlices(toFloat64s(elements), toFloat64s(y), math.Mod)
+}
+
 // Unwrap This unwraps a signal p by changing elements which have an absolute difference from their predecessor of
 //more than max(discont, period/2) to their period-complementary values.
-func Unwrap(array []float64) []float64 {
-	result := []float64{array[0]}
-	dd := Diff(array)
+func Unwrap[T Number](array []T) []float64 {
+	values := toFloat64s(array)
+	result := []float64{values[0]}
+	dd := Diff(values)
 	discont := math.Pi
 	period := 2 * math.Pi
 	intervalHigh := period / 2
@@ -480,18 +523,18 @@ func Unwrap(array []float64) []float64 {
 			ddmod[i] = intervalHigh
 		}
 	}
-	phCorrect := SumWith(ddmod, MultiplyBy(dd, -1))
+	phCorrect := SumWithElems(ddmod, MultiplyBy(dd, -1))
 	for i := range phCorrect {
 		if Abs(dd)[i] < discont {
 			ddmod[i] = 0
 		}
 	}
-	summed := SumWith(array[1:], Cumsum(phCorrect))
+	summed := SumWithElems(values[1:], Cumsum(phCorrect))
 	result = append(result, summed...)
 	return result
 }
 
 // Norm returns the norm of an array
-func Norm(x []float64) float64 {
+func Norm[T Number](x []T) float64 {
 	return math.Sqrt(Sum(Pow(x, 2)))
 }
@@ -1,6 +1,7 @@
 package vectors
 
 import (
+	"errors"
 	"math"
 	"reflect"
 	"testing"
@@ -12,6 +13,63 @@ func TestMultiplyBy(t *testing.T) {
 	if reflect.DeepEqual(expected, output) != true {
 		t.Errorf("MultiplyBy(%v,%d) = %v, want %v", testSliceFloat, 2, output, expected)
 	}
+
+	// a slice of length one is broadcast against the other
+	expected = []float64{3, 6}
+	output = MultiplyByElems([]float64{3}, []float64{1, 2})
+	if reflect.DeepEqual(expected, output) != true {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	defer func() {
+		if _, ok := recover().(*ShapeError); !ok {
+			t.Errorf("MultiplyByElems with mismatched lengths did not panic with a ShapeError")
+		}
+	}()
+	MultiplyByElems([]float64{1, 2, 3}, []float64{1, 2})
+}
+
+func TestMultiplyByGeneric(t *testing.T) {
+	// every integer and float type works as the factor and as the elements
+	expected := []float64{2, 4, 6}
+	for _, output := range [][]float64{
+		MultiplyByElems([]float64{1, 2, 3}, []int{2, 2, 2}),
+		MultiplyBy([]float64{1, 2, 3}, int64(2)),
+		MultiplyBy([]float64{1, 2, 3}, int32(2)),
+		MultiplyBy([]float64{1, 2, 3}, uint8(2)),
+		MultiplyBy([]int{1, 2, 3}, float32(2)),
+		MultiplyBy([]uint{2, 4, 6}, 1.0),
+		SumWithElems([]int16{0, 2, 4}, []int64{2, 2, 2}),
+		SumWith([]int8{1, 3, 5}, uint(1)),
+		PowElems([]int{2, 4, 6}, []uint8{1}),
+	} {
+		if reflect.DeepEqual(expected, output) != true {
+			t.Errorf("Got %v, want %v", output, expected)
+		}
+	}
+
+	if output, _ := Max([]int{3, 9, 4}); output != 9 {
+		t.Errorf("Max = %v, want 9", output)
+	}
+	if output := Sum([]uint8{1, 2, 3}); output != 6 {
+		t.Errorf("Sum = %v, want 6", output)
+	}
+	if output := Mean([]int{1, 2}); output != 1.5 {
+		t.Errorf("Mean = %v, want 1.5", output)
+	}
+	// integer matrices and rounding keep their element type
+	if output := Matmul([][]int{{1, 2}, {3, 4}}, [][]int{{1}, {1}}); reflect.DeepEqual([][]int{{3}, {7}}, output) != true {
+		t.Errorf("Matmul = %v, want [[3] [7]]", output)
+	}
+	if output := Round([]int32{1234, -1250}, -2); reflect.DeepEqual([]int32{1200, -1300}, output) != true {
+		t.Errorf("Round = %v, want [1200 -1300]", output)
+	}
+	if output := Norm([]int{3, 4}); output != 5 {
+		t.Errorf("Norm = %v, want 5", output)
+	}
+	if !AllClose([]uint16{1, 2}, []uint16{1, 2}, 0) || AllClose([]int{1, 2}, []int{1, 3}, 0.5) {
+		t.Errorf("AllClose compares integer slices incorrectly")
+	}
 }
 
 func TestPow(t *testing.T) {
@@ -102,10 +160,13 @@ func TestAbs2D(t *testing.T) {
 
 func TestSumWith(t *testing.T) {
 	expected := []float64{2.2, 2.4, 2.6, 2.8, 3, 2.2}
-	output := SumWith(testSliceFloat, testSliceFloat)
+	output := SumWithElems(testSliceFloat, testSliceFloat)
 
 	if reflect.DeepEqual(expected, output) != true {
-		t.Errorf("SumWith(%v,%v) = %v, want %v", testSliceFloat, testSliceFloat, output, expected)
+		t.Errorf("SumWithElems(%v,%v) = %v, want %v", testSliceFloat, testSliceFloat, output, expected)
+	}
+	if output := SumWith([]float64{1, 2}, 0.5); reflect.DeepEqual([]float64{1.5, 2.5}, output) != true {
+		t.Errorf("Got %v, want [1.5 2.5]", output)
 	}
 }
 
@@ -206,10 +267,13 @@ func TestRealIfClose(t *testing.T) {
 func TestFloorDivide(t *testing.T) {
 	expected := []float64{1, 1, 1, 0, 0, 0}
 	testDenominator := []float64{1, 1.2, 1.3, 2.9, 3.1, 4.4}
-	output := FloorDivide(testSliceFloat, testDenominator)
+	output := FloorDivideElems(testSliceFloat, testDenominator)
 	if reflect.DeepEqual(expected, output) != true {
 		t.Errorf("Got %v, want %v", output, expected)
 	}
+	if output := FloorDivide([]int{7, -7}, 2); reflect.DeepEqual([]float64{3, -4}, output) != true {
+		t.Errorf("Got %v, want [3 -4]", output)
+	}
 }
 
 func TestDiff(t *testing.T) {
@@ -256,6 +320,9 @@ func TestInterp(t *testing.T) {
 	if reflect.DeepEqual(expected, Round(output, 2)) != true {
 		t.Errorf("Got %v, want %v", output, expected)
 	}
+	if output := ModElems([]int{7, 8}, []int{4, 5}); reflect.DeepEqual([]float64{3, 3}, output) != true {
+		t.Errorf("Got %v, want [3 3]", output)
+	}
 }
 
 func TestInverse(t *testing.T) {
@@ -270,15 +337,43 @@ func TestInverse(t *testing.T) {
 	}
 }
 
-func TestPolyfit(t *testing.T) {
-	expected := []float64{0.64, 0.55}
-	x := []float64{1, 3, 4, 6, 8, 9, 11, 14}
-	y := []float64{1, 2, 4, 4, 5, 7, 8, 9}
-	a, b := Polyfit(x, y)
-	output := []float64{a, b}
-	if reflect.DeepEqual(expected, Round(output, 2)) != true {
-		t.Errorf("Got %v, want %v", output, expected)
+func TestTryErrors(t *testing.T) {
+	_, err := TryDividedBy([]float64{1, 2, 3}, []float64{1, 2})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("TryDividedBy = %v, want %v", err, ErrShapeMismatch)
+	}
+	if _, err := TryDot([]float64{1}, []float64{1, 2}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("TryDot = %v, want %v", err, ErrShapeMismatch)
+	}
+	if _, err := TryMatmul([][]float64{{1, 2}}, [][]float64{{1, 2}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("TryMatmul = %v, want %v", err, ErrShapeMismatch)
 	}
+	if _, err := TryMatmul(nil, [][]float64{{1}}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryMatmul = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := TryInverse([][]float64{{1, 2}, {2, 4}}); !errors.Is(err, ErrSingular) {
+		t.Errorf("TryInverse = %v, want %v", err, ErrSingular)
+	}
+	if _, err := TryInterp([]float64{1}, nil, nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryInterp = %v, want %v", err, ErrEmpty)
+	}
+	if _, _, err := TryMax([]float64{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryMax = %v, want %v", err, ErrEmpty)
+	}
+	if _, _, err := TryMin([]int{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryMin = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := TryCumsum([]float64{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryCumsum = %v, want %v", err, ErrEmpty)
+	}
+
+	// the panicking variants panic with the same errors
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrShapeMismatch) {
+			t.Errorf("Dot panicked with %v, want %v", err, ErrShapeMismatch)
+		}
+	}()
+	Dot([]float64{1}, []float64{1, 2})
 }
 
 func TestMod(t *testing.T) {
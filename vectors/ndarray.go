@@ -0,0 +1,346 @@
+package vectors
+
+import (
+	"fmt"
+)
+
+// NDArray is an n-dimensional array of float64 values stored in a flat buffer. The element at index
+// (i0, i1, ...) is data[offset + i0*strides[0] + i1*strides[1] + ...], so transposing, slicing and, for
+// contiguous arrays, reshaping return views that share the buffer instead of copying it.
+type NDArray struct {
+	data     []float64
+	shape    []int
+	strides  []int
+	offset   int
+	readOnly bool
+}
+
+// NewNDArray returns an array of the given shape backed by data in row-major order, without copying it.
+func NewNDArray(data []float64, shape ...int) *NDArray {
+	size := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			panic(fmt.Sprintf("ndarray: negative dimension in shape %v", shape))
+		}
+		size *= dim
+	}
+	if size != len(data) {
+		panic(fmt.Sprintf("ndarray: cannot create an array of shape %v from %d values", shape, len(data)))
+	}
+	return &NDArray{data: data, shape: append([]int{}, shape...), strides: rowMajorStrides(shape)}
+}
+
+// NDZeros returns an array of the given shape filled with zeros.
+func NDZeros(shape ...int) *NDArray {
+	size := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			panic(fmt.Sprintf("ndarray: negative dimension in shape %v", shape))
+		}
+		size *= dim
+	}
+	return NewNDArray(make([]float64, size), shape...)
+}
+
+// NDFromSlice returns a 1-D array holding a copy of array converted to float64.
+func NDFromSlice[T Number](array []T) *NDArray {
+	return NewNDArray(append([]float64{}, toFloat64s(array)...), len(array))
+}
+
+// NDFrom2D returns a 2-D array holding a copy of the rows of array converted to float64, which must all
+// have the same length.
+func NDFrom2D[T Number](array [][]T) *NDArray {
+	if !CheckConsistency(array) {
+		panic("ndarray: rows must have the same length")
+	}
+	cols := 0
+	if len(array) > 0 {
+		cols = len(array[0])
+	}
+	data := make([]float64, 0, len(array)*cols)
+	for _, row := range array {
+		data = append(data, toFloat64s(row)...)
+	}
+	return NewNDArray(data, len(array), cols)
+}
+
+// rowMajorStrides returns the strides of a contiguous array of the given shape
+func rowMajorStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	return strides
+}
+
+// Shape returns the length of each dimension.
+func (array *NDArray) Shape() []int {
+	return append([]int{}, array.shape...)
+}
+
+// Strides returns the distance in elements between consecutive indices of each dimension.
+func (array *NDArray) Strides() []int {
+	return append([]int{}, array.strides...)
+}
+
+// Ndim returns the number of dimensions.
+func (array *NDArray) Ndim() int {
+	return len(array.shape)
+}
+
+// Size returns the number of elements.
+func (array *NDArray) Size() int {
+	size := 1
+	for _, dim := range array.shape {
+		size *= dim
+	}
+	return size
+}
+
+// IsContiguous reports whether the elements are laid out in row-major order without gaps.
+func (array *NDArray) IsContiguous() bool {
+	stride := 1
+	for i := len(array.shape) - 1; i >= 0; i-- {
+		if array.shape[i] != 1 && array.strides[i] != stride {
+			return false
+		}
+		stride *= array.shape[i]
+	}
+	return true
+}
+
+// position returns the buffer position of the element at index, counting negative indices from the end
+func (array *NDArray) position(index []int) int {
+	if len(index) != len(array.shape) {
+		panic(fmt.Sprintf("ndarray: %d indices given for an array with %d dimensions", len(index), len(array.shape)))
+	}
+	pos := array.offset
+	for axis, i := range index {
+		if i < 0 {
+			i += array.shape[axis]
+		}
+		if i < 0 || i >= array.shape[axis] {
+			panic(fmt.Sprintf("ndarray: index %d is out of range for axis %d with size %d", index[axis], axis, array.shape[axis]))
+		}
+		pos += i * array.strides[axis]
+	}
+	return pos
+}
+
+// At returns the element at index.
+func (array *NDArray) At(index ...int) float64 {
+	return array.data[array.position(index)]
+}
+
+// Set stores value at index, which is visible through every view of the same buffer. It panics on a
+// read-only view such as the one returned by BroadcastTo.
+func (array *NDArray) Set(value float64, index ...int) {
+	if array.readOnly {
+		panic("ndarray: assignment to a read-only view")
+	}
+	array.data[array.position(index)] = value
+}
+
+// forEach calls f with the buffer position of every element in row-major order
+func (array *NDArray) forEach(f func(pos int)) {
+	if array.Size() == 0 {
+		return
+	}
+	ndim := len(array.shape)
+	index := make([]int, ndim)
+	pos := array.offset
+	for {
+		f(pos)
+		axis := ndim - 1
+		for ; axis >= 0; axis-- {
+			index[axis]++
+			pos += array.strides[axis]
+			if index[axis] < array.shape[axis] {
+				break
+			}
+			pos -= index[axis] * array.strides[axis]
+			index[axis] = 0
+		}
+		if axis < 0 {
+			return
+		}
+	}
+}
+
+// Flatten returns a copy of the elements in row-major order.
+func (array *NDArray) Flatten() []float64 {
+	result := make([]float64, 0, array.Size())
+	array.forEach(func(pos int) {
+		result = append(result, array.data[pos])
+	})
+	return result
+}
+
+// Copy returns a contiguous copy of the array that does not share its buffer.
+func (array *NDArray) Copy() *NDArray {
+	return NewNDArray(array.Flatten(), array.shape...)
+}
+
+// To2D returns the rows of a 2-D array as slices.
+func (array *NDArray) To2D() [][]float64 {
+	if len(array.shape) != 2 {
+		panic(fmt.Sprintf("ndarray: To2D requires a 2-D array, got %d dimensions", len(array.shape)))
+	}
+	flat := array.Flatten()
+	result := make([][]float64, array.shape[0])
+	for i := range result {
+		result[i] = flat[i*array.shape[1] : (i+1)*array.shape[1] : (i+1)*array.shape[1]]
+	}
+	return result
+}
+
+// Reshape returns the array with a new shape holding the same number of elements, one dimension of
+// which may be -1 to be inferred. Contiguous arrays are reshaped without copying, others are copied.
+func (array *NDArray) Reshape(shape ...int) *NDArray {
+	shape = append([]int{}, shape...)
+	size, inferred := 1, -1
+	for i, dim := range shape {
+		switch {
+		case dim == -1 && inferred < 0:
+			inferred = i
+		case dim < 0:
+			panic(fmt.Sprintf("ndarray: invalid shape %v", shape))
+		default:
+			size *= dim
+		}
+	}
+	if inferred >= 0 {
+		if size == 0 || array.Size()%size != 0 {
+			panic(fmt.Sprintf("ndarray: cannot reshape an array of size %d into shape %v", array.Size(), shape))
+		}
+		shape[inferred] = array.Size() / size
+		size *= shape[inferred]
+	}
+	if size != array.Size() {
+		panic(fmt.Sprintf("ndarray: cannot reshape an array of size %d into shape %v", array.Size(), shape))
+	}
+	if !array.IsContiguous() {
+		return NewNDArray(array.Flatten(), shape...)
+	}
+	return &NDArray{data: array.data, shape: shape, strides: rowMajorStrides(shape), offset: array.offset, readOnly: array.readOnly}
+}
+
+// Transpose returns a view with the axes permuted to the given order, or reversed when none are given.
+func (array *NDArray) Transpose(axes ...int) *NDArray {
+	ndim := len(array.shape)
+	if len(axes) == 0 {
+		for i := ndim - 1; i >= 0; i-- {
+			axes = append(axes, i)
+		}
+	}
+	if len(axes) != ndim {
+		panic(fmt.Sprintf("ndarray: axes %v do not match an array with %d dimensions", axes, ndim))
+	}
+	view := &NDArray{data: array.data, shape: make([]int, ndim), strides: make([]int, ndim), offset: array.offset, readOnly: array.readOnly}
+	seen := make([]bool, ndim)
+	for i, axis := range axes {
+		if axis < 0 {
+			axis += ndim
+		}
+		if axis < 0 || axis >= ndim || seen[axis] {
+			panic(fmt.Sprintf("ndarray: axes %v are not a permutation of the dimensions", axes))
+		}
+		seen[axis] = true
+		view.shape[i], view.strides[i] = array.shape[axis], array.strides[axis]
+	}
+	return view
+}
+
+// T returns the transposed view of the array.
+func (array *NDArray) T() *NDArray {
+	return array.Transpose()
+}
+
+// Slice returns a view of the elements start, start+step, ... before stop along axis. Negative start and
+// stop count from the end and out of range values are clipped, as with NumPy's start:stop:step.
+func (array *NDArray) Slice(axis, start, stop, step int) *NDArray {
+	axis = array.checkAxis(axis)
+	if step <= 0 {
+		panic("ndarray: slice step must be positive")
+	}
+	dim := array.shape[axis]
+	clip := func(i int) int {
+		if i < 0 {
+			i += dim
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > dim {
+			return dim
+		}
+		return i
+	}
+	start, stop = clip(start), clip(stop)
+	length := 0
+	if stop > start {
+		length = (stop - start + step - 1) / step
+	}
+
+	view := &NDArray{data: array.data, shape: array.Shape(), strides: array.Strides(), offset: array.offset, readOnly: array.readOnly}
+	if length > 0 {
+		view.offset += start * array.strides[axis]
+	}
+	view.shape[axis] = length
+	view.strides[axis] *= step
+	return view
+}
+
+// Index returns the view of the elements with index i along axis, which has one dimension less. For
+// example, Index(1, j) of a matrix is its j-th column.
+func (array *NDArray) Index(axis, i int) *NDArray {
+	axis = array.checkAxis(axis)
+	if i < 0 {
+		i += array.shape[axis]
+	}
+	if i < 0 || i >= array.shape[axis] {
+		panic(fmt.Sprintf("ndarray: index %d is out of range for axis %d with size %d", i, axis, array.shape[axis]))
+	}
+	view := &NDArray{data: array.data, offset: array.offset + i*array.strides[axis], readOnly: array.readOnly}
+	view.shape = append(append(view.shape, array.shape[:axis]...), array.shape[axis+1:]...)
+	view.strides = append(append(view.strides, array.strides[:axis]...), array.strides[axis+1:]...)
+	return view
+}
+
+// checkAxis returns axis counted from the front, panicking when it is out of range
+func (array *NDArray) checkAxis(axis int) int {
+	ndim := len(array.shape)
+	if axis < 0 {
+		axis += ndim
+	}
+	if axis < 0 || axis >= ndim {
+		panic(fmt.Sprintf("ndarray: axis %d is out of range for an array with %d dimensions", axis, ndim))
+	}
+	return axis
+}
+
+// String formats the array as nested lists.
+func (array *NDArray) String() string {
+	flat := array.Flatten()
+	var format func(axis, start int) string
+	format = func(axis, start int) string {
+		if axis == len(array.shape) {
+			return fmt.Sprint(flat[start])
+		}
+		stride := 1
+		for _, dim := range array.shape[axis+1:] {
+			stride *= dim
+		}
+		result := "["
+		for i := 0; i < array.shape[axis]; i++ {
+			if i > 0 {
+				result += " "
+			}
+			result += format(axis+1, start+i*stride)
+		}
+		return result + "]"
+	}
+	return format(0, 0)
+}
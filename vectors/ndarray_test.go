@@ -0,0 +1,110 @@
+package vectors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewNDArray(t *testing.T) {
+	array := NewNDArray(Arange(0.0, 24, 1), 2, 3, 4)
+	if !reflect.DeepEqual([]int{2, 3, 4}, array.Shape()) || !reflect.DeepEqual([]int{12, 4, 1}, array.Strides()) {
+		t.Errorf("Got shape %v and strides %v", array.Shape(), array.Strides())
+	}
+	if array.Ndim() != 3 || array.Size() != 24 || array.At(1, 2, 3) != 23 || array.At(-1, 0, -2) != 14 {
+		t.Errorf("Got ndim %d, size %d, elements %v and %v", array.Ndim(), array.Size(), array.At(1, 2, 3), array.At(-1, 0, -2))
+	}
+
+	expected := [][]float64{{1, 2}, {3, 4}, {5, 6}}
+	if output := NDFrom2D(expected).To2D(); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := NDFromSlice([]float64{1, 2, 3}).Flatten(); !reflect.DeepEqual([]float64{1, 2, 3}, output) {
+		t.Errorf("Got %v, want [1 2 3]", output)
+	}
+	if output := NDZeros(2, 0).Size(); output != 0 {
+		t.Errorf("Got size %d, want 0", output)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewNDArray with a mismatched shape did not panic")
+		}
+	}()
+	NewNDArray([]float64{1, 2, 3}, 2, 2)
+}
+
+func TestNDArrayReshape(t *testing.T) {
+	data := Arange(0.0, 12, 1)
+	array := NewNDArray(data, 3, 4)
+	reshaped := array.Reshape(2, -1, 3)
+	if !reflect.DeepEqual([]int{2, 2, 3}, reshaped.Shape()) || reshaped.At(1, 1, 2) != 11 {
+		t.Errorf("Got shape %v and element %v", reshaped.Shape(), reshaped.At(1, 1, 2))
+	}
+	// reshaping a contiguous array shares the buffer
+	reshaped.Set(-1, 0, 0, 0)
+	if data[0] != -1 {
+		t.Errorf("Reshape copied a contiguous array")
+	}
+
+	// a transposed array is copied into the new shape
+	expected := []float64{-1, 4, 8, 1, 5, 9, 2, 6, 10, 3, 7, 11}
+	output := array.T().Reshape(12)
+	if !reflect.DeepEqual(expected, output.Flatten()) {
+		t.Errorf("Got %v, want %v", output.Flatten(), expected)
+	}
+	output.Set(100, 0)
+	if data[0] != -1 {
+		t.Errorf("Reshape of a non-contiguous array shares the buffer")
+	}
+}
+
+func TestNDArrayTranspose(t *testing.T) {
+	array := NewNDArray(Arange(0.0, 6, 1), 2, 3)
+	expected := [][]float64{{0, 3}, {1, 4}, {2, 5}}
+	if output := array.T().To2D(); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if array.T().IsContiguous() || !array.T().T().IsContiguous() {
+		t.Errorf("Transposed views report the wrong contiguity")
+	}
+
+	cube := NewNDArray(Arange(0.0, 24, 1), 2, 3, 4).Transpose(1, 2, 0)
+	if !reflect.DeepEqual([]int{3, 4, 2}, cube.Shape()) || cube.At(2, 1, 1) != 21 {
+		t.Errorf("Got shape %v and element %v", cube.Shape(), cube.At(2, 1, 1))
+	}
+}
+
+func TestNDArraySlice(t *testing.T) {
+	data := Arange(0.0, 20, 1)
+	array := NewNDArray(data, 4, 5)
+
+	expected := [][]float64{{6, 8}, {16, 18}}
+	view := array.Slice(0, 1, 4, 2).Slice(1, 1, -1, 2)
+	if output := view.To2D(); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	view.Set(-8, 0, 1)
+	if data[8] != -8 {
+		t.Errorf("Slice copied the buffer")
+	}
+
+	if output := array.Slice(1, 3, 100, 1).Shape(); !reflect.DeepEqual([]int{4, 2}, output) {
+		t.Errorf("Got shape %v, want [4 2]", output)
+	}
+	if output := array.Slice(0, 3, 1, 1).Size(); output != 0 {
+		t.Errorf("Got size %d, want 0", output)
+	}
+
+	// Index drops the axis, giving a column view without copying
+	column := array.Index(1, 2)
+	expectedColumn := []float64{2, 7, 12, 17}
+	if output := column.Flatten(); !reflect.DeepEqual(expectedColumn, output) || column.Ndim() != 1 {
+		t.Errorf("Got %v, want %v", output, expectedColumn)
+	}
+	if output := array.Index(0, -1).Flatten(); !reflect.DeepEqual([]float64{15, 16, 17, 18, 19}, output) {
+		t.Errorf("Got %v, want [15 16 17 18 19]", output)
+	}
+	if output := array.Slice(1, 0, 2, 1).String(); output != "[[0 1] [5 6] [10 11] [15 16]]" {
+		t.Errorf("Got %s", output)
+	}
+}
@@ -0,0 +1,584 @@
+package vectors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CurveFitOptions configures CurveFit. The zero value selects the scipy defaults.
+type CurveFitOptions struct {
+	// Sigma holds the uncertainty of each y value, the residuals are divided by it.
+	Sigma []float64
+	// AbsoluteSigma uses Sigma in an absolute sense instead of scaling pcov by the reduced chi-square.
+	AbsoluteSigma bool
+	// MaxFev is the total number of evaluations of the model over the data allowed before the fit gives up,
+	// defaulting to 200 * (len(p0) + 1). Each estimate of the Jacobian costs len(p0) of them.
+	MaxFev int
+	// Ftol, Xtol and Gtol are the convergence tolerances on the cost, the parameters and the gradient,
+	// each defaulting to 1e-8.
+	Ftol, Xtol, Gtol float64
+}
+
+// CurveFit uses non-linear least squares to fit the model f(x, p) to the data y, starting from the
+// parameters p0. bounds holds the lower and upper bounds of the parameters and may be nil. Unbounded
+// problems are solved with the Levenberg-Marquardt method, bounded problems with a projected variant
+// that keeps the parameters inside the box and holds those on an active bound fixed. The optimal
+// parameters and their estimated covariance are returned.
+func CurveFit(f func(x float64, p []float64) float64, x, y, p0 []float64, bounds [][]float64, opts CurveFitOptions) ([]float64, [][]float64, error) {
+	n := len(p0)
+	switch {
+	case len(x) != len(y):
+		return nil, nil, fmt.Errorf("curve_fit: %w: x and y must have the same length", ErrShapeMismatch)
+	case n == 0:
+		return nil, nil, errors.New("curve_fit: p0 must contain at least one parameter")
+	case len(x) < n:
+		return nil, nil, fmt.Errorf("curve_fit: %d data points are not enough to fit %d parameters", len(x), n)
+	case opts.Sigma != nil && len(opts.Sigma) != len(y):
+		return nil, nil, fmt.Errorf("curve_fit: %w: sigma must have the same length as y", ErrShapeMismatch)
+	}
+
+	lower := Repeat(math.Inf(-1), n)
+	upper := Repeat(math.Inf(1), n)
+	if bounds != nil {
+		if len(bounds) != 2 || len(bounds[0]) != n || len(bounds[1]) != n {
+			return nil, nil, fmt.Errorf("curve_fit: %w: bounds must hold a lower and an upper bound for each parameter", ErrShapeMismatch)
+		}
+		lower, upper = bounds[0], bounds[1]
+		for i := range p0 {
+			if lower[i] >= upper[i] {
+				return nil, nil, errors.New("curve_fit: each lower bound must be strictly less than the upper bound")
+			}
+			if p0[i] < lower[i] || p0[i] > upper[i] {
+				return nil, nil, errors.New("curve_fit: p0 is infeasible")
+			}
+		}
+	}
+
+	residuals := func(p []float64) []float64 {
+		r := make([]float64, len(x))
+		for i := range x {
+			r[i] = f(x[i], p) - y[i]
+			if opts.Sigma != nil {
+				r[i] /= opts.Sigma[i]
+			}
+		}
+		return r
+	}
+
+	maxFev := opts.MaxFev
+	if maxFev == 0 {
+		maxFev = 200 * (n + 1)
+	}
+	p, jac, cost, err := levenbergMarquardt(residuals, p0, lower, upper, maxFev, opts.Ftol, opts.Xtol, opts.Gtol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the covariance is the inverse of J^T J, scaled by the reduced chi-square unless sigma is absolute
+	pcov, ok := invertMatrix(Matmul(Transpose(jac), jac))
+	if !ok {
+		return p, infMatrix(n), nil
+	}
+	if !opts.AbsoluteSigma {
+		if len(y) <= n {
+			return p, infMatrix(n), nil
+		}
+		sSq := cost / float64(len(y)-n)
+		for i := range pcov {
+			for j := range pcov[i] {
+				pcov[i][j] *= sSq
+			}
+		}
+	}
+	return p, pcov, nil
+}
+
+// infMatrix returns an n by n matrix filled with +Inf, the covariance of parameters that cannot be estimated
+func infMatrix(n int) [][]float64 {
+	var result [][]float64
+	for i := 0; i < n; i++ {
+		result = append(result, Repeat(math.Inf(1), n))
+	}
+	return result
+}
+
+// levenbergMarquardt minimizes the sum of squares of residuals(p) within the box [lower, upper] and
+// returns the solution, the Jacobian of the residuals at the solution and the final sum of squares
+func levenbergMarquardt(residuals func([]float64) []float64, p0, lower, upper []float64, maxFev int, ftol, xtol, gtol float64) ([]float64, [][]float64, float64, error) {
+	if ftol == 0 {
+		ftol = 1e-8
+	}
+	if xtol == 0 {
+		xtol = 1e-8
+	}
+	if gtol == 0 {
+		gtol = 1e-8
+	}
+	n := len(p0)
+	p := append([]float64{}, p0...)
+	r := residuals(p)
+	nfev := 1
+	cost := Dot(r, r)
+	if math.IsNaN(cost) || math.IsInf(cost, 0) {
+		return nil, nil, 0, errors.New("curve_fit: residuals are not finite at p0")
+	}
+
+	jac := forwardJacobian(residuals, p, r, lower, upper)
+	nfev += n
+	if !finiteMatrix(jac) {
+		return nil, nil, 0, fmt.Errorf("curve_fit: the Jacobian is not finite at p = %v", p)
+	}
+	lambda := 1e-3
+	nu := 2.0
+	for nfev < maxFev {
+		// normal equations of the damped Gauss-Newton step
+		jtj := Matmul(Transpose(jac), jac)
+		grad := make([]float64, n)
+		for j := 0; j < n; j++ {
+			for i := range r {
+				grad[j] += jac[i][j] * r[i]
+			}
+		}
+
+		// parameters on a bound that the gradient pushes outwards are held fixed for this step
+		free := make([]bool, n)
+		for j := 0; j < n; j++ {
+			free[j] = !(p[j] <= lower[j] && grad[j] > 0) && !(p[j] >= upper[j] && grad[j] < 0)
+		}
+
+		// the gradient is orthogonal to the columns of the Jacobian at the optimum
+		var gradNorm float64
+		for j := 0; j < n; j++ {
+			if free[j] && jtj[j][j] > 0 {
+				gradNorm = math.Max(gradNorm, math.Abs(grad[j])/math.Sqrt(jtj[j][j]*cost))
+			}
+		}
+		if cost == 0 || gradNorm <= gtol {
+			return p, jac, cost, nil
+		}
+
+		var freeIdx []int
+		for j := 0; j < n; j++ {
+			if free[j] {
+				freeIdx = append(freeIdx, j)
+			}
+		}
+		if len(freeIdx) == 0 {
+			return p, jac, cost, nil
+		}
+		damped := Zeros(len(freeIdx), len(freeIdx))
+		for a, i := range freeIdx {
+			for b, j := range freeIdx {
+				damped[a][b] = jtj[i][j]
+			}
+			damped[a][a] += lambda * math.Max(jtj[i][i], 1e-12)
+		}
+		inv, ok := invertMatrix(damped)
+		if !ok {
+			// a damping that no longer fits in a float64 cannot make the system solvable
+			lambda *= nu
+			nu *= 2
+			if math.IsInf(lambda, 0) {
+				return nil, nil, 0, errors.New("curve_fit: the damped normal equations stay singular")
+			}
+			continue
+		}
+
+		// take the step over the free parameters and project it back into the feasible box
+		trial := append([]float64{}, p...)
+		for a, i := range freeIdx {
+			var step float64
+			for b, j := range freeIdx {
+				step -= inv[a][b] * grad[j]
+			}
+			trial[i] = math.Min(math.Max(p[i]+step, lower[i]), upper[i])
+		}
+		step := make([]float64, n)
+		for i := range step {
+			step[i] = trial[i] - p[i]
+		}
+		trialR := residuals(trial)
+		nfev++
+		trialCost := Dot(trialR, trialR)
+
+		// reduction predicted by the quadratic model ||r + J s||^2 for the projected step
+		var predicted float64
+		for i := 0; i < n; i++ {
+			predicted -= 2 * grad[i] * step[i]
+			for j := 0; j < n; j++ {
+				predicted -= step[i] * jtj[i][j] * step[j]
+			}
+		}
+		actual := cost - trialCost
+		small := Norm(step) <= xtol*(xtol+Norm(p))
+
+		if !math.IsNaN(trialCost) && actual > 0 && predicted > 0 {
+			rho := actual / predicted
+			lambda *= math.Max(1.0/3, 1-math.Pow(2*rho-1, 3))
+			nu = 2
+			converged := (actual <= ftol*cost && predicted <= ftol*cost) || small
+			p, r, cost = trial, trialR, trialCost
+			jac = forwardJacobian(residuals, p, r, lower, upper)
+			nfev += n
+			if !finiteMatrix(jac) {
+				return nil, nil, 0, fmt.Errorf("curve_fit: the Jacobian is not finite at p = %v", p)
+			}
+			if converged {
+				return p, jac, cost, nil
+			}
+		} else {
+			if small && lambda > 1e10 {
+				return p, jac, cost, nil
+			}
+			lambda *= nu
+			nu *= 2
+		}
+	}
+	return nil, nil, 0, fmt.Errorf("curve_fit: optimal parameters not found, the number of calls to the function has reached maxfev = %d", maxFev)
+}
+
+// finiteMatrix reports whether every element of a matrix is finite
+func finiteMatrix(array [][]float64) bool {
+	for _, row := range array {
+		for _, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// forwardJacobian approximates the Jacobian of residuals at p with one-sided differences that stay
+// within [lower, upper] like scipy's _adjust_scheme_to_bounds: a step leaving the box is reversed when it
+// fits on the other side, and otherwise shortened to the distance to the farther bound
+func forwardJacobian(residuals func([]float64) []float64, p, r, lower, upper []float64) [][]float64 {
+	n := len(p)
+	jac := Zeros(len(r), n)
+	eps := math.Sqrt(2.220446049250313e-16)
+	for j := 0; j < n; j++ {
+		h := eps * math.Max(math.Abs(p[j]), 1)
+		if p[j]+h > upper[j] || p[j]+h < lower[j] {
+			lowerDist, upperDist := p[j]-lower[j], upper[j]-p[j]
+			switch {
+			case h <= math.Max(lowerDist, upperDist):
+				h = -h
+			case upperDist >= lowerDist:
+				h = upperDist
+			default:
+				h = -lowerDist
+			}
+		}
+		shifted := append([]float64{}, p...)
+		shifted[j] += h
+		h = shifted[j] - p[j]
+		rh := residuals(shifted)
+		for i := range r {
+			jac[i][j] = (rh[i] - r[i]) / h
+		}
+	}
+	return jac
+}
+
+// FSolveOptions configures FSolve. The zero value selects the scipy defaults.
+type FSolveOptions struct {
+	// Jacobian returns the matrix of partial derivatives df[i]/dx[j] at x. It is estimated with forward
+	// differences when nil.
+	Jacobian func(x []float64) [][]float64
+	// Xtol is the relative error between two consecutive iterates at which the solve stops, defaulting
+	// to 1.49012e-8.
+	Xtol float64
+	// MaxFev is the maximum number of calls to f, defaulting to 200 * (len(x0) + 1), or to
+	// 100 * (len(x0) + 1) when the Jacobian is given.
+	MaxFev int
+	// Factor sets the initial step bound to Factor times the scaled norm of x0, defaulting to 100.
+	Factor float64
+}
+
+// FSolveResult holds the outcome of FSolve.
+type FSolveResult struct {
+	// X is the final estimate of the root and Fvec the function evaluated at X.
+	X, Fvec []float64
+	// Ier is 1 when a solution was found, the other values are those of scipy.optimize.fsolve.
+	Ier int
+	// Message describes the reason the solve stopped.
+	Message string
+	// Nfev and Njev count the calls to the function and to the Jacobian.
+	Nfev, Njev int
+}
+
+// FSolve finds a root of the system of n equations f(x) = 0 in n unknowns, starting from x0. It uses the
+// hybrid method of Powell as implemented by MINPACK's hybrd and hybrj: dogleg steps between the
+// Gauss-Newton and the steepest descent directions inside a trust region, with rank-one Broyden updates
+// of the Jacobian that is recomputed when the steps keep failing. Convergence failures are reported
+// through Ier and Message of the result, the error is reserved for invalid input.
+func FSolve(f func([]float64) []float64, x0 []float64, opts FSolveOptions) (FSolveResult, error) {
+	n := len(x0)
+	if n == 0 {
+		return FSolveResult{}, errors.New("fsolve: x0 must contain at least one value")
+	}
+	xtol := opts.Xtol
+	if xtol == 0 {
+		xtol = 1.49012e-8
+	}
+	maxFev := opts.MaxFev
+	if maxFev == 0 {
+		maxFev = 200 * (n + 1)
+		if opts.Jacobian != nil {
+			maxFev = 100 * (n + 1)
+		}
+	}
+	factor := opts.Factor
+	if factor == 0 {
+		factor = 100
+	}
+
+	// every evaluation of f is checked, a wrong length is recorded and replaced by zeros so that the
+	// caller can return the error
+	var shapeErr error
+	evaluate := func(v []float64) []float64 {
+		values := f(v)
+		if len(values) != n {
+			if shapeErr == nil {
+				shapeErr = fmt.Errorf("fsolve: %w: f returned %d values for %d unknowns", ErrShapeMismatch, len(values), n)
+			}
+			return make([]float64, n)
+		}
+		return values
+	}
+
+	result := FSolveResult{X: append([]float64{}, x0...)}
+	x := result.X
+	fx := evaluate(x)
+	result.Nfev++
+	if shapeErr != nil {
+		return FSolveResult{}, shapeErr
+	}
+	jacobian := func() ([][]float64, error) {
+		if opts.Jacobian == nil {
+			result.Nfev += n
+			jac := forwardJacobian(evaluate, x, fx, Repeat(math.Inf(-1), n), Repeat(math.Inf(1), n))
+			return jac, shapeErr
+		}
+		result.Njev++
+		// the matrix is copied since the Broyden updates modify it in place
+		user := opts.Jacobian(x)
+		if len(user) != n {
+			return nil, fmt.Errorf("fsolve: %w: the Jacobian must be a %d by %d matrix", ErrShapeMismatch, n, n)
+		}
+		jac := make([][]float64, n)
+		for i, row := range user {
+			if len(row) != n {
+				return nil, fmt.Errorf("fsolve: %w: the Jacobian must be a %d by %d matrix", ErrShapeMismatch, n, n)
+			}
+			jac[i] = append([]float64{}, row...)
+		}
+		return jac, nil
+	}
+
+	eps := 2.220446049250313e-16
+	diag := make([]float64, n)
+	scaledNorm := func(v []float64) float64 {
+		var norm float64
+		for j := range v {
+			norm = math.Hypot(norm, diag[j]*v[j])
+		}
+		return norm
+	}
+	finish := func(ier int, message string) (FSolveResult, error) {
+		result.Fvec, result.Ier, result.Message = fx, ier, message
+		return result, nil
+	}
+
+	fnorm := Norm(fx)
+	var jac [][]float64
+	var delta, xnorm float64
+	var err error
+	iter := 0
+	ncfail, ncsuc, nslow1, nslow2 := 0, 0, 0, 0
+	jeval := true
+	for {
+		if jeval {
+			if jac, err = jacobian(); err != nil {
+				return FSolveResult{}, err
+			}
+			// the variables are scaled by the largest column norms of the Jacobian seen so far
+			for j := 0; j < n; j++ {
+				var colNorm float64
+				for i := 0; i < n; i++ {
+					colNorm = math.Hypot(colNorm, jac[i][j])
+				}
+				if iter == 0 && colNorm == 0 {
+					colNorm = 1
+				}
+				diag[j] = math.Max(diag[j], colNorm)
+			}
+			if iter == 0 {
+				xnorm = scaledNorm(x)
+				delta = factor * xnorm
+				if delta == 0 {
+					delta = factor
+				}
+			}
+		}
+		if fnorm == 0 {
+			return finish(1, "The solution converged.")
+		}
+
+		p := doglegStep(jac, fx, diag, delta)
+		pnorm := scaledNorm(p)
+		if iter == 0 {
+			delta = math.Min(delta, pnorm)
+		}
+		trial := make([]float64, n)
+		for j := range x {
+			trial[j] = x[j] + p[j]
+		}
+		ft := evaluate(trial)
+		result.Nfev++
+		if shapeErr != nil {
+			return FSolveResult{}, shapeErr
+		}
+		fnorm1 := Norm(ft)
+
+		// compare the actual reduction of the residual with the one predicted by the linear model
+		actred := -1.0
+		if fnorm1 < fnorm {
+			actred = 1 - (fnorm1/fnorm)*(fnorm1/fnorm)
+		}
+		predicted := make([]float64, n)
+		for i := 0; i < n; i++ {
+			predicted[i] = fx[i]
+			for j := 0; j < n; j++ {
+				predicted[i] += jac[i][j] * p[j]
+			}
+		}
+		var prered, ratio float64
+		if temp := Norm(predicted); temp < fnorm {
+			prered = 1 - (temp/fnorm)*(temp/fnorm)
+		}
+		if prered > 0 {
+			ratio = actred / prered
+		}
+
+		if ratio < 0.1 {
+			ncsuc = 0
+			ncfail++
+			delta *= 0.5
+		} else {
+			ncfail = 0
+			ncsuc++
+			if ratio >= 0.5 || ncsuc > 1 {
+				delta = math.Max(delta, pnorm/0.5)
+			}
+			if math.Abs(ratio-1) <= 0.1 {
+				delta = pnorm / 0.5
+			}
+		}
+		if ratio >= 1e-4 {
+			copy(x, trial)
+			fx, fnorm = ft, fnorm1
+			xnorm = scaledNorm(x)
+			iter++
+		}
+
+		nslow1++
+		if actred >= 0.001 {
+			nslow1 = 0
+		}
+		if jeval {
+			nslow2++
+		}
+		if actred >= 0.1 {
+			nslow2 = 0
+		}
+
+		switch {
+		case delta <= xtol*xnorm || fnorm == 0:
+			return finish(1, "The solution converged.")
+		case result.Nfev >= maxFev:
+			return finish(2, fmt.Sprintf("The number of calls to function has reached maxfev = %d.", maxFev))
+		case 0.1*math.Max(0.1*delta, pnorm) <= eps*xnorm:
+			return finish(3, fmt.Sprintf("xtol=%f is too small, no further improvement in the approximate solution is possible.", xtol))
+		case nslow2 == 5:
+			return finish(4, "The iteration is not making good progress, as measured by the improvement from the last five Jacobian evaluations.")
+		case nslow1 == 10:
+			return finish(5, "The iteration is not making good progress, as measured by the improvement from the last ten iterations.")
+		}
+
+		// recompute the Jacobian after two failed steps, otherwise apply the Broyden rank-one update
+		jeval = ncfail == 2
+		if jeval {
+			continue
+		}
+		pp := Dot(p, p)
+		if pp == 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			diff := ft[i] - predicted[i]
+			for j := 0; j < n; j++ {
+				jac[i][j] += diff * p[j] / pp
+			}
+		}
+	}
+}
+
+// doglegStep returns the step minimizing ||fx + jac p|| along Powell's dogleg path within the trust region
+// ||diag p|| <= delta
+func doglegStep(jac [][]float64, fx, diag []float64, delta float64) []float64 {
+	n := len(fx)
+	// Gauss-Newton step, expressed in the scaled variables z = diag p
+	gn := leastSquares(jac, MultiplyBy(fx, -1))
+	zgn := make([]float64, n)
+	for j := range gn {
+		zgn[j] = diag[j] * gn[j]
+	}
+	if Norm(zgn) <= delta {
+		return gn
+	}
+
+	// steepest descent direction of the scaled problem and the minimizer of the model along it
+	grad := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			grad[j] += jac[i][j] * fx[i]
+		}
+		grad[j] /= diag[j]
+	}
+	jg := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			jg[i] += jac[i][j] * grad[j] / diag[j]
+		}
+	}
+	z := make([]float64, n)
+	gradNorm, jgNorm := Norm(grad), Norm(jg)
+	if gradNorm == 0 || jgNorm == 0 {
+		// no descent information, fall back to the truncated Gauss-Newton step
+		z = MultiplyBy(zgn, delta/Norm(zgn))
+	} else {
+		alpha := (gradNorm / jgNorm) * (gradNorm / jgNorm)
+		zsd := MultiplyBy(grad, -alpha)
+		if sdNorm := Norm(zsd); sdNorm >= delta {
+			z = MultiplyBy(zsd, delta/sdNorm)
+		} else {
+			// walk from the Cauchy point towards the Gauss-Newton step up to the trust region boundary
+			d := make([]float64, n)
+			for j := range d {
+				d[j] = zgn[j] - zsd[j]
+			}
+			a, b, c := Dot(d, d), 2*Dot(zsd, d), sdNorm*sdNorm-delta*delta
+			tau := (-b + math.Sqrt(b*b-4*a*c)) / (2 * a)
+			for j := range z {
+				z[j] = zsd[j] + tau*d[j]
+			}
+		}
+	}
+	p := make([]float64, n)
+	for j := range z {
+		p[j] = z[j] / diag[j]
+	}
+	return p
+}
@@ -0,0 +1,180 @@
+package vectors
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCurveFit(t *testing.T) {
+	model := func(x float64, p []float64) float64 {
+		return p[0]*math.Exp(-p[1]*x) + p[2]
+	}
+	x := LinSpace(0, 4, 50)
+	y := Apply(x, func(v float64) float64 { return model(v, []float64{2.5, 1.3, 0.5}) })
+
+	popt, pcov, err := CurveFit(model, x, y, []float64{1, 1, 1}, nil, CurveFitOptions{})
+	if err != nil {
+		t.Fatalf("CurveFit returned error %v", err)
+	}
+	if !AllClose([]float64{2.5, 1.3, 0.5}, popt, 1e-6) {
+		t.Errorf("Got %v, want %v", popt, []float64{2.5, 1.3, 0.5})
+	}
+	if len(pcov) != 3 || len(pcov[0]) != 3 {
+		t.Errorf("pcov has shape %dx%d, want 3x3", len(pcov), len(pcov[0]))
+	}
+
+	// the active bound holds the parameter at its limit
+	bounds := [][]float64{{0, 0, 0.6}, {10, 10, 10}}
+	popt, _, err = CurveFit(model, x, y, []float64{1, 1, 1}, bounds, CurveFitOptions{})
+	if err != nil {
+		t.Fatalf("CurveFit returned error %v", err)
+	}
+	if popt[2] != 0.6 {
+		t.Errorf("Got %v, want the last parameter at its lower bound 0.6", popt)
+	}
+	// a box narrower than the finite-difference step is never left while estimating the Jacobian
+	narrow := [][]float64{{0, 0, 0.5}, {10, 10, 0.5 + 1e-10}}
+	guarded := func(x float64, p []float64) float64 {
+		if p[2] < narrow[0][2] || p[2] > narrow[1][2] {
+			t.Fatalf("model evaluated at %v outside the bounds", p)
+		}
+		return model(x, p)
+	}
+	for _, p0 := range [][]float64{{1, 1, narrow[0][2]}, {1, 1, narrow[1][2]}} {
+		if _, _, err := CurveFit(guarded, x, y, p0, narrow, CurveFitOptions{}); err != nil {
+			t.Errorf("CurveFit from %v returned error %v", p0, err)
+		}
+	}
+
+	// a model that overflows next to p0 gives an infinite Jacobian, which is reported instead of retried
+	p0 := []float64{1, 1, 1}
+	overflowing := func(x float64, p []float64) float64 {
+		if p[0] != p0[0] || p[1] != p0[1] || p[2] != p0[2] {
+			return math.Inf(1)
+		}
+		return model(x, p)
+	}
+	if _, _, err := CurveFit(overflowing, x, y, p0, nil, CurveFitOptions{}); err == nil {
+		t.Errorf("CurveFit with an infinite Jacobian returned no error")
+	}
+
+	if _, _, err := CurveFit(model, x, y[1:], []float64{1, 1, 1}, nil, CurveFitOptions{}); err == nil {
+		t.Errorf("CurveFit with mismatched lengths returned no error")
+	}
+	if _, _, err := CurveFit(model, x, y, []float64{1, 1, 1}, [][]float64{{2, 2, 2}, {3, 3, 3}}, CurveFitOptions{}); err == nil {
+		t.Errorf("CurveFit with infeasible p0 returned no error")
+	}
+}
+
+func TestCurveFitCovariance(t *testing.T) {
+	// for a straight line the covariance matches ordinary least squares: s^2 (X^T X)^-1
+	line := func(x float64, p []float64) float64 { return p[0]*x + p[1] }
+	x := []float64{1, 3, 4, 6, 8, 9, 11, 14}
+	y := []float64{1, 2, 4, 4, 5, 7, 8, 9}
+	popt, pcov, err := CurveFit(line, x, y, []float64{1, 0}, nil, CurveFitOptions{})
+	if err != nil {
+		t.Fatalf("CurveFit returned error %v", err)
+	}
+	if !AllClose([]float64{0.63636364, 0.54545455}, popt, 1e-7) {
+		t.Errorf("Got %v, want %v", popt, []float64{0.63636364, 0.54545455})
+	}
+
+	n := float64(len(x))
+	sxx := Dot(x, x) - Sum(x)*Sum(x)/n
+	var rss float64
+	for i := range x {
+		rss += math.Pow(y[i]-line(x[i], popt), 2)
+	}
+	s2 := rss / (n - 2)
+	expected := [][]float64{
+		{s2 / sxx, -s2 * Mean(x) / sxx},
+		{-s2 * Mean(x) / sxx, s2 * (1/n + Mean(x)*Mean(x)/sxx)},
+	}
+	for i := range expected {
+		if !AllClose(expected[i], pcov[i], 1e-8) {
+			t.Errorf("Got %v, want %v", pcov, expected)
+		}
+	}
+
+	// with absolute sigma the covariance is (J^T W J)^-1
+	sigma := Repeat(0.5, len(x))
+	_, pcov, _ = CurveFit(line, x, y, []float64{1, 0}, nil, CurveFitOptions{Sigma: sigma, AbsoluteSigma: true})
+	if math.Abs(pcov[0][0]-0.25/sxx) > 1e-8 {
+		t.Errorf("Got %v, want %v", pcov[0][0], 0.25/sxx)
+	}
+}
+
+func TestFSolve(t *testing.T) {
+	// x0 cos(x1) = 4, x0 x1 - x1 = 5 from the scipy.optimize.fsolve documentation
+	f := func(x []float64) []float64 {
+		return []float64{x[0]*math.Cos(x[1]) - 4, x[1]*x[0] - x[1] - 5}
+	}
+	expected := []float64{6.50409711, 0.90841421}
+	result, err := FSolve(f, []float64{1, 1}, FSolveOptions{})
+	if err != nil || result.Ier != 1 || !AllClose(expected, result.X, 1e-8) {
+		t.Errorf("Got %v, %v, want %v", result, err, expected)
+	}
+	if !AllClose([]float64{0, 0}, result.Fvec, 1e-10) || result.Nfev == 0 {
+		t.Errorf("Got residual %v after %d calls", result.Fvec, result.Nfev)
+	}
+
+	// an analytic Jacobian reaches the same root
+	jac := func(x []float64) [][]float64 {
+		return [][]float64{{math.Cos(x[1]), -x[0] * math.Sin(x[1])}, {x[1], x[0] - 1}}
+	}
+	result, err = FSolve(f, []float64{1, 1}, FSolveOptions{Jacobian: jac})
+	if err != nil || result.Ier != 1 || !AllClose(expected, result.X, 1e-8) || result.Njev == 0 {
+		t.Errorf("Got %v, %v, want %v", result, err, expected)
+	}
+
+	// the Rosenbrock system of the MINPACK test set has its root at (1, 1)
+	rosen := func(x []float64) []float64 {
+		return []float64{1 - x[0], 10 * (x[1] - x[0]*x[0])}
+	}
+	result, _ = FSolve(rosen, []float64{-1.2, 1}, FSolveOptions{})
+	if result.Ier != 1 || !AllClose([]float64{1, 1}, result.X, 1e-6) {
+		t.Errorf("Got %v, want [1 1]", result)
+	}
+
+	// x^2 + 1 has no real root
+	result, _ = FSolve(func(x []float64) []float64 { return []float64{x[0]*x[0] + 1} }, []float64{3}, FSolveOptions{})
+	if result.Ier == 1 || result.Message == "" {
+		t.Errorf("FSolve without a root reported %v", result)
+	}
+
+	result, _ = FSolve(f, []float64{1, 1}, FSolveOptions{MaxFev: 4})
+	if result.Ier != 2 || result.Nfev > 4+2 {
+		t.Errorf("FSolve with MaxFev 4 reported %v", result)
+	}
+
+	// the matrix returned by the Jacobian is left untouched by the Broyden updates
+	fixed := [][]float64{{2, 1}, {1, 3}}
+	linear := func(x []float64) []float64 {
+		return []float64{2*x[0] + x[1] - 3 + 0.1*x[0]*x[0], x[0] + 3*x[1] - 4}
+	}
+	result, err = FSolve(linear, []float64{5, -5}, FSolveOptions{Jacobian: func([]float64) [][]float64 { return fixed }})
+	if err != nil || fixed[0][0] != 2 || fixed[0][1] != 1 || fixed[1][0] != 1 || fixed[1][1] != 3 {
+		t.Errorf("FSolve modified the Jacobian to %v, error %v", fixed, err)
+	}
+
+	if _, err := FSolve(f, []float64{1, 2, 3}, FSolveOptions{}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("FSolve with mismatched shapes returned %v", err)
+	}
+	// a wrong length after the first call is reported as well
+	calls := 0
+	shrinking := func(x []float64) []float64 {
+		calls++
+		if calls > 1 {
+			return []float64{x[0]}
+		}
+		return f(x)
+	}
+	if _, err := FSolve(shrinking, []float64{1, 1}, FSolveOptions{}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("FSolve with a function changing its length returned %v", err)
+	}
+	calls = 0
+	if _, err := FSolve(shrinking, []float64{1, 1}, FSolveOptions{Jacobian: jac}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("FSolve with a function changing its length returned %v", err)
+	}
+}
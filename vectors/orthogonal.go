@@ -0,0 +1,388 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// orthogonalBasis describes a family of orthogonal polynomials with P0 = 1, P1 = x and the three-term
+// recurrence P(n+1) = alpha(n) x P(n) - beta(n) P(n-1), along with one step of term-wise differentiation
+// and integration of a series in the family. companion returns the scaled companion matrix of a series
+// of degree two or more, symmetric when the series is a single basis polynomial, whose eigenvalues are its
+// roots.
+type orthogonalBasis struct {
+	name       string
+	recurrence func(n int) (alpha, beta float64)
+	deriv      func(c []float64) []float64
+	integ      func(c []float64) []float64
+	companion  func(c []float64) [][]float64
+}
+
+var chebyshevBasis = orthogonalBasis{
+	name: "chebyshev",
+	recurrence: func(n int) (float64, float64) {
+		return 2, 1
+	},
+	deriv: func(c []float64) []float64 {
+		n := len(c)
+		der := make([]float64, n-1)
+		for j := n - 1; j > 2; j-- {
+			der[j-1] = float64(2*j) * c[j]
+			c[j-2] += float64(j) * c[j] / float64(j-2)
+		}
+		if n > 2 {
+			der[1] = 4 * c[2]
+		}
+		der[0] = c[1]
+		return der
+	},
+	integ: func(c []float64) []float64 {
+		n := len(c)
+		result := make([]float64, n+1)
+		result[1] = c[0]
+		if n > 1 {
+			result[2] = c[1] / 4
+		}
+		for j := 2; j < n; j++ {
+			result[j+1] = c[j] / float64(2*(j+1))
+			result[j-1] -= c[j] / float64(2*(j-1))
+		}
+		return result
+	},
+	// the colleague matrix of numpy.polynomial.chebyshev.chebcompanion
+	companion: func(c []float64) [][]float64 {
+		n := len(c) - 1
+		mat := Zeros(n, n)
+		scl := Repeat(math.Sqrt(0.5), n)
+		scl[0] = 1
+		for i := 0; i < n-1; i++ {
+			mat[i][i+1], mat[i+1][i] = 0.5, 0.5
+		}
+		mat[0][1], mat[1][0] = math.Sqrt(0.5), math.Sqrt(0.5)
+		for i := 0; i < n; i++ {
+			mat[i][n-1] -= c[i] / c[n] * (scl[i] / scl[n-1]) * 0.5
+		}
+		return mat
+	},
+}
+
+var legendreBasis = orthogonalBasis{
+	name: "legendre",
+	recurrence: func(n int) (float64, float64) {
+		return float64(2*n+1) / float64(n+1), float64(n) / float64(n+1)
+	},
+	deriv: func(c []float64) []float64 {
+		n := len(c)
+		der := make([]float64, n-1)
+		for j := n - 1; j > 2; j-- {
+			der[j-1] = float64(2*j-1) * c[j]
+			c[j-2] += c[j]
+		}
+		if n > 2 {
+			der[1] = 3 * c[2]
+		}
+		der[0] = c[1]
+		return der
+	},
+	integ: func(c []float64) []float64 {
+		n := len(c)
+		result := make([]float64, n+1)
+		result[1] = c[0]
+		if n > 1 {
+			result[2] = c[1] / 3
+		}
+		for j := 2; j < n; j++ {
+			t := c[j] / float64(2*j+1)
+			result[j+1] = t
+			result[j-1] -= t
+		}
+		return result
+	},
+	// the comrade matrix of numpy.polynomial.legendre.legcompanion
+	companion: func(c []float64) [][]float64 {
+		n := len(c) - 1
+		mat := Zeros(n, n)
+		scl := make([]float64, n)
+		for i := range scl {
+			scl[i] = 1 / math.Sqrt(float64(2*i+1))
+		}
+		for i := 0; i < n-1; i++ {
+			mat[i][i+1] = float64(i+1) * scl[i] * scl[i+1]
+			mat[i+1][i] = mat[i][i+1]
+		}
+		for i := 0; i < n; i++ {
+			mat[i][n-1] -= c[i] / c[n] * (scl[i] / scl[n-1]) * float64(n) / float64(2*n-1)
+		}
+		return mat
+	},
+}
+
+// values returns P0(x), ..., P(deg)(x)
+func (basis orthogonalBasis) values(x float64, deg int) []float64 {
+	values := make([]float64, deg+1)
+	values[0] = 1
+	if deg > 0 {
+		values[1] = x
+	}
+	for n := 1; n < deg; n++ {
+		alpha, beta := basis.recurrence(n)
+		values[n+1] = alpha*x*values[n] - beta*values[n-1]
+	}
+	return values
+}
+
+// powers returns the power series coefficients, lowest power first, of P0, ..., P(deg)
+func (basis orthogonalBasis) powers(deg int) [][]float64 {
+	powers := Zeros(deg+1, deg+1)
+	powers[0][0] = 1
+	if deg > 0 {
+		powers[1][1] = 1
+	}
+	for n := 1; n < deg; n++ {
+		alpha, beta := basis.recurrence(n)
+		for i := 0; i <= n; i++ {
+			powers[n+1][i+1] += alpha * powers[n][i]
+			powers[n+1][i] -= beta * powers[n-1][i]
+		}
+	}
+	return powers
+}
+
+// eval returns the value of the series with coefficients c at each point of x
+func (basis orthogonalBasis) eval(c []float64, x []float64) []float64 {
+	result := make([]float64, len(x))
+	if len(c) == 0 {
+		return result
+	}
+	for i, xi := range x {
+		result[i] = Dot(c, basis.values(xi, len(c)-1))
+	}
+	return result
+}
+
+// derivative returns the m-th derivative of the series with coefficients c
+func (basis orthogonalBasis) derivative(c []float64, m int) []float64 {
+	if m < 0 {
+		panic(fmt.Sprintf("%s: the order of the derivative must be non-negative", basis.name))
+	}
+	result := append([]float64{}, c...)
+	for ; m > 0; m-- {
+		if len(result) < 2 {
+			return []float64{0}
+		}
+		result = basis.deriv(result)
+	}
+	return result
+}
+
+// integral returns the m-th antiderivative of the series with coefficients c, the constant of the i-th
+// integration chosen so that its value at zero is k[i]
+func (basis orthogonalBasis) integral(c []float64, m int, k []float64) []float64 {
+	if m < 0 {
+		panic(fmt.Sprintf("%s: the order of the integral must be non-negative", basis.name))
+	}
+	if k != nil && len(k) != m {
+		panic(fmt.Sprintf("%s: %d integration constants given for an integral of order %d", basis.name, len(k), m))
+	}
+	result := append([]float64{}, c...)
+	if len(result) == 0 {
+		result = []float64{0}
+	}
+	for i := 0; i < m; i++ {
+		result = basis.integ(result)
+		result[0] -= basis.eval(result, []float64{0})[0]
+		if k != nil {
+			result[0] += k[i]
+		}
+	}
+	return result
+}
+
+// toPoly returns the power series of the series with coefficients c
+func (basis orthogonalBasis) toPoly(c []float64) *Poly1D {
+	if len(c) == 0 {
+		return NewPoly1D(nil)
+	}
+	powers := basis.powers(len(c) - 1)
+	coeffs := make([]float64, len(c))
+	for n, cn := range c {
+		for i, p := range powers[n] {
+			coeffs[len(c)-1-i] += cn * p
+		}
+	}
+	return NewPoly1D(coeffs)
+}
+
+// fromPoly returns the coefficients in the basis of the power series poly
+func (basis orthogonalBasis) fromPoly(poly *Poly1D) []float64 {
+	deg := poly.Order()
+	powers := basis.powers(deg)
+	remainder := make([]float64, deg+1)
+	for i, p := range poly.coeffs {
+		remainder[deg-i] = p
+	}
+	// P(n) has degree n, so the coefficients follow from the highest power down
+	c := make([]float64, deg+1)
+	for n := deg; n >= 0; n-- {
+		c[n] = remainder[n] / powers[n][n]
+		for i := 0; i <= n; i++ {
+			remainder[i] -= c[n] * powers[n][i]
+		}
+	}
+	return c
+}
+
+// roots returns the roots of the series with coefficients c, sorted by real and then imaginary part, as
+// the eigenvalues of its companion matrix
+func (basis orthogonalBasis) roots(c []float64) []complex128 {
+	for len(c) > 0 && c[len(c)-1] == 0 {
+		c = c[:len(c)-1]
+	}
+	var roots []complex128
+	switch {
+	case len(c) < 2:
+		return []complex128{}
+	case len(c) == 2:
+		roots = []complex128{complex(-c[0]/c[1], 0)}
+	default:
+		// numpy reverses the rows and columns before computing the eigenvalues
+		mat := basis.companion(c)
+		n := len(mat)
+		flipped := Zeros(n, n)
+		for i := range mat {
+			for j := range mat[i] {
+				flipped[n-1-i][n-1-j] = mat[i][j]
+			}
+		}
+		values, _, err := eigenNonsymmetric(flipped)
+		if err != nil {
+			panic(fmt.Errorf("%sroots: %w", basis.name, err))
+		}
+		roots = values
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		if real(roots[i]) != real(roots[j]) {
+			return real(roots[i]) < real(roots[j])
+		}
+		return imag(roots[i]) < imag(roots[j])
+	})
+	return roots
+}
+
+// fit returns the coefficients of the least-squares fit of degree deg to the points x, y
+func (basis orthogonalBasis) fit(x, y []float64, deg int) ([]float64, error) {
+	switch {
+	case deg < 0:
+		return nil, fmt.Errorf("%sfit: expected deg >= 0", basis.name)
+	case len(x) != len(y):
+		return nil, fmt.Errorf("%sfit: %w: x and y must have the same length", basis.name, ErrShapeMismatch)
+	case len(x) == 0:
+		return nil, fmt.Errorf("%sfit: %w: no data points", basis.name, ErrEmpty)
+	}
+	lhs := make([][]float64, len(x))
+	for i, xi := range x {
+		lhs[i] = basis.values(xi, deg)
+	}
+	coeffs, _, rank, _, _, err := scaledLstsq(lhs, append([]float64{}, y...), float64(len(x))*2.220446049250313e-16)
+	if err != nil {
+		return nil, err
+	}
+	if rank != deg+1 {
+		return nil, fmt.Errorf("%sfit: %w: the fit is poorly conditioned, rank %d for degree %d", basis.name, ErrSingular, rank, deg)
+	}
+	return coeffs, nil
+}
+
+// Chebyshev is a series of Chebyshev polynomials of the first kind like numpy.polynomial.Chebyshev with
+// the default domain, its coefficients are given in order of increasing degree.
+type Chebyshev struct {
+	Coef []float64
+}
+
+// ChebyshevFit returns the Chebyshev series of degree deg that fits the points x, y in the least-squares
+// sense like numpy.polynomial.chebyshev.chebfit. The x values are used as they are, for a well
+// conditioned fit they should lie in [-1, 1].
+func ChebyshevFit(x, y []float64, deg int) (Chebyshev, error) {
+	c, err := chebyshevBasis.fit(x, y, deg)
+	return Chebyshev{Coef: c}, err
+}
+
+// ChebyshevFromPoly returns the Chebyshev series equal to the polynomial poly.
+func ChebyshevFromPoly(poly *Poly1D) Chebyshev {
+	return Chebyshev{Coef: chebyshevBasis.fromPoly(poly)}
+}
+
+// Eval returns the value of the series at each point of x.
+func (series Chebyshev) Eval(x []float64) []float64 {
+	return chebyshevBasis.eval(series.Coef, x)
+}
+
+// Deriv returns the m-th derivative of the series.
+func (series Chebyshev) Deriv(m int) Chebyshev {
+	return Chebyshev{Coef: chebyshevBasis.derivative(series.Coef, m)}
+}
+
+// Integ returns the m-th antiderivative of the series, the i-th integration adding a constant that makes
+// its value at zero k[i]. k may be nil for zeros.
+func (series Chebyshev) Integ(m int, k []float64) Chebyshev {
+	return Chebyshev{Coef: chebyshevBasis.integral(series.Coef, m, k)}
+}
+
+// ToPoly returns the series converted to the power basis.
+func (series Chebyshev) ToPoly() *Poly1D {
+	return chebyshevBasis.toPoly(series.Coef)
+}
+
+// Roots returns the roots of the series sorted by real and then imaginary part, the eigenvalues of its
+// colleague matrix like numpy.polynomial.chebyshev.chebroots, which avoids the ill conditioning of the
+// power series for high degrees.
+func (series Chebyshev) Roots() []complex128 {
+	return chebyshevBasis.roots(series.Coef)
+}
+
+// Legendre is a series of Legendre polynomials like numpy.polynomial.Legendre with the default domain,
+// its coefficients are given in order of increasing degree.
+type Legendre struct {
+	Coef []float64
+}
+
+// LegendreFit returns the Legendre series of degree deg that fits the points x, y in the least-squares
+// sense like numpy.polynomial.legendre.legfit. The x values are used as they are, for a well conditioned
+// fit they should lie in [-1, 1].
+func LegendreFit(x, y []float64, deg int) (Legendre, error) {
+	c, err := legendreBasis.fit(x, y, deg)
+	return Legendre{Coef: c}, err
+}
+
+// LegendreFromPoly returns the Legendre series equal to the polynomial poly.
+func LegendreFromPoly(poly *Poly1D) Legendre {
+	return Legendre{Coef: legendreBasis.fromPoly(poly)}
+}
+
+// Eval returns the value of the series at each point of x.
+func (series Legendre) Eval(x []float64) []float64 {
+	return legendreBasis.eval(series.Coef, x)
+}
+
+// Deriv returns the m-th derivative of the series.
+func (series Legendre) Deriv(m int) Legendre {
+	return Legendre{Coef: legendreBasis.derivative(series.Coef, m)}
+}
+
+// Integ returns the m-th antiderivative of the series, the i-th integration adding a constant that makes
+// its value at zero k[i]. k may be nil for zeros.
+func (series Legendre) Integ(m int, k []float64) Legendre {
+	return Legendre{Coef: legendreBasis.integral(series.Coef, m, k)}
+}
+
+// ToPoly returns the series converted to the power basis.
+func (series Legendre) ToPoly() *Poly1D {
+	return legendreBasis.toPoly(series.Coef)
+}
+
+// Roots returns the roots of the series sorted by real and then imaginary part, the eigenvalues of its
+// comrade matrix like numpy.polynomial.legendre.legroots.
+func (series Legendre) Roots() []complex128 {
+	return legendreBasis.roots(series.Coef)
+}
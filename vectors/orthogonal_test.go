@@ -0,0 +1,102 @@
+package vectors
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestChebyshev(t *testing.T) {
+	series := Chebyshev{Coef: []float64{1, 2, 3}}
+	// 1 + 2x + 3(2x^2 - 1)
+	if output := series.ToPoly().Coeffs(); !reflect.DeepEqual([]float64{6, 2, -2}, output) {
+		t.Errorf("Got %v, want [6 2 -2]", output)
+	}
+	if output := series.Eval([]float64{-1, 0, 0.5}); !AllClose([]float64{2, -2, 0.5}, output, 1e-14) {
+		t.Errorf("Got %v, want [2 -2 0.5]", output)
+	}
+	if output := ChebyshevFromPoly(NewPoly1D([]float64{6, 2, -2})).Coef; !AllClose(series.Coef, output, 1e-14) {
+		t.Errorf("Got %v, want %v", output, series.Coef)
+	}
+	if output := (Chebyshev{Coef: []float64{1, 2, 3, 4}}).Deriv(1).Coef; !reflect.DeepEqual([]float64{14, 12, 24}, output) {
+		t.Errorf("Got %v, want [14 12 24]", output)
+	}
+	if output := series.Integ(1, nil).Coef; !AllClose([]float64{0.5, -0.5, 0.5, 0.5}, output, 1e-15) {
+		t.Errorf("Got %v, want [0.5 -0.5 0.5 0.5]", output)
+	}
+
+	x := LinSpace(-1, 1, 11)
+	fit, err := ChebyshevFit(x, series.Eval(x), 2)
+	if err != nil || !AllClose(series.Coef, fit.Coef, 1e-12) {
+		t.Errorf("Got %v and error %v, want %v", fit.Coef, err, series.Coef)
+	}
+	roots := Real((Chebyshev{Coef: []float64{0, 0, 1}}).Roots())
+	sort.Float64s(roots)
+	if expected := []float64{-math.Sqrt(0.5), math.Sqrt(0.5)}; !AllClose(expected, roots, 1e-12) {
+		t.Errorf("Got %v, want %v", roots, expected)
+	}
+	// the colleague matrix finds the roots cos((2k+1)pi/80) of T40, which the power series cannot
+	coef := make([]float64, 41)
+	coef[40] = 1
+	roots = Real((Chebyshev{Coef: coef}).Roots())
+	for k := range roots {
+		if expected := math.Cos(float64(2*(39-k)+1) * math.Pi / 80); math.Abs(roots[k]-expected) > 1e-13 {
+			t.Errorf("Got root %v, want %v", roots[k], expected)
+		}
+	}
+	// 2 T0 + T2 = 2x^2 + 1 has the complex roots -+i/sqrt(2), sorted by imaginary part
+	complexRoots := (Chebyshev{Coef: []float64{2, 0, 1}}).Roots()
+	if expected := []complex128{complex(0, -math.Sqrt(0.5)), complex(0, math.Sqrt(0.5))}; !complexClose(expected, complexRoots, 1e-12) {
+		t.Errorf("Got %v, want %v", complexRoots, expected)
+	}
+	// trailing zero coefficients do not add roots
+	if output := (Chebyshev{Coef: []float64{3, 2, 0}}).Roots(); len(output) != 1 || output[0] != -1.5 {
+		t.Errorf("Got %v, want [-1.5]", output)
+	}
+}
+
+func TestLegendre(t *testing.T) {
+	series := Legendre{Coef: []float64{1, 2, 3}}
+	// 1 + 2x + 3(3x^2 - 1)/2
+	if output := series.ToPoly().Coeffs(); !AllClose([]float64{4.5, 2, -0.5}, output, 1e-15) {
+		t.Errorf("Got %v, want [4.5 2 -0.5]", output)
+	}
+	if output := LegendreFromPoly(series.ToPoly()).Coef; !AllClose(series.Coef, output, 1e-14) {
+		t.Errorf("Got %v, want %v", output, series.Coef)
+	}
+	if output := (Legendre{Coef: []float64{1, 2, 3, 4}}).Deriv(1).Coef; !reflect.DeepEqual([]float64{6, 9, 20}, output) {
+		t.Errorf("Got %v, want [6 9 20]", output)
+	}
+	expected := []float64{1.0 / 3, 0.4, 2.0 / 3, 0.6}
+	if output := series.Integ(1, nil).Coef; !AllClose(expected, output, 1e-15) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := series.Integ(1, nil).Deriv(1).Coef; !AllClose(series.Coef, output, 1e-15) {
+		t.Errorf("Got %v, want %v", output, series.Coef)
+	}
+
+	x := LinSpace(-1, 1, 9)
+	fit, err := LegendreFit(x, series.Eval(x), 3)
+	if err != nil || !AllClose([]float64{1, 2, 3, 0}, fit.Coef, 1e-12) {
+		t.Errorf("Got %v and error %v, want [1 2 3 0]", fit.Coef, err)
+	}
+	if _, err := LegendreFit([]float64{0.5, 0.5}, []float64{1, 2}, 1); err == nil {
+		t.Errorf("LegendreFit of a single abscissa did not fail")
+	}
+
+	// the roots of P5 are 0 and +-sqrt(5 +- 2 sqrt(10/7))/3
+	coef := []float64{0, 0, 0, 0, 0, 1}
+	inner, outer := math.Sqrt(5-2*math.Sqrt(10.0/7))/3, math.Sqrt(5+2*math.Sqrt(10.0/7))/3
+	if roots := Real((Legendre{Coef: coef}).Roots()); !AllClose([]float64{-outer, -inner, 0, inner, outer}, roots, 1e-14) {
+		t.Errorf("Got %v, want %v", roots, []float64{-outer, -inner, 0, inner, outer})
+	}
+	// the comrade matrix keeps the roots of P30 accurate
+	coef = make([]float64, 31)
+	coef[30] = 1
+	series = Legendre{Coef: coef}
+	roots := Real(series.Roots())
+	if len(roots) != 30 || !AllClose(make([]float64, 30), series.Eval(roots), 1e-12) {
+		t.Errorf("Got %v with values %v", roots, series.Eval(roots))
+	}
+}
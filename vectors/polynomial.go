@@ -0,0 +1,402 @@
+package vectors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PolyfitOptions holds the optional settings of Polyfit.
+type PolyfitOptions struct {
+	// W holds a weight for each point, applied to the unsquared residuals. For gaussian uncertainties
+	// use 1/sigma.
+	W []float64
+	// Rcond is the relative cutoff below which singular values of the Vandermonde matrix are neglected,
+	// defaulting to len(x) times machine precision.
+	Rcond float64
+	// Cov requests the covariance matrix of the coefficients, scaled by the reduced chi-square unless
+	// Unscaled is set, as for weights that are the reciprocal of known uncertainties.
+	Cov, Unscaled bool
+}
+
+// PolyfitResult holds the coefficients of a polynomial fit and its diagnostics.
+type PolyfitResult struct {
+	// Coefficients holds the polynomial coefficients, highest power first.
+	Coefficients []float64
+	// Residuals holds the sum of the squared weighted residuals, it is empty when the fit is rank
+	// deficient or has no more points than coefficients.
+	Residuals []float64
+	// Rank and SingularValues describe the scaled Vandermonde matrix and Rcond is the cutoff used.
+	Rank           int
+	SingularValues []float64
+	Rcond          float64
+	// Cov is the covariance matrix of the coefficients when requested.
+	Cov [][]float64
+}
+
+// Polyfit returns the coefficients, highest power first, of the polynomial of degree deg that fits the
+// points x, y in the least-squares sense like numpy.polyfit.
+func Polyfit(x, y []float64, deg int, opts PolyfitOptions) []float64 {
+	coeffs, err := TryPolyfit(x, y, deg, opts)
+	if err != nil {
+		panic(err)
+	}
+	return coeffs
+}
+
+// TryPolyfit is Polyfit returning ErrShapeMismatch, ErrEmpty or ErrSingular instead of panicking. A rank
+// deficient fit, for which numpy only warns, is reported as ErrSingular.
+func TryPolyfit(x, y []float64, deg int, opts PolyfitOptions) ([]float64, error) {
+	result, err := PolyfitFull(x, y, deg, opts)
+	if err != nil {
+		return nil, err
+	}
+	if result.Rank != deg+1 {
+		return nil, fmt.Errorf("polyfit: %w: the fit is poorly conditioned, rank %d for degree %d", ErrSingular, result.Rank, deg)
+	}
+	return result.Coefficients, nil
+}
+
+// PolyfitFull fits the polynomial of degree deg like Polyfit and also returns the residuals, rank and
+// singular values of the fit and, when opts.Cov is set, the covariance of the coefficients. The columns of
+// the Vandermonde matrix are scaled to unit norm before solving, which improves the conditioning.
+func PolyfitFull(x, y []float64, deg int, opts PolyfitOptions) (PolyfitResult, error) {
+	order := deg + 1
+	switch {
+	case deg < 0:
+		return PolyfitResult{}, errors.New("polyfit: expected deg >= 0")
+	case len(x) != len(y):
+		return PolyfitResult{}, fmt.Errorf("polyfit: %w: x and y must have the same length", ErrShapeMismatch)
+	case len(x) == 0:
+		return PolyfitResult{}, fmt.Errorf("polyfit: %w: no data points", ErrEmpty)
+	case opts.W != nil && len(opts.W) != len(x):
+		return PolyfitResult{}, fmt.Errorf("polyfit: %w: w must have the same length as x", ErrShapeMismatch)
+	}
+	rcond := opts.Rcond
+	if rcond <= 0 {
+		rcond = float64(len(x)) * 2.220446049250313e-16
+	}
+
+	lhs := Zeros(len(x), order)
+	rhs := append([]float64{}, y...)
+	for i, xi := range x {
+		power := 1.0
+		for j := order - 1; j >= 0; j-- {
+			lhs[i][j] = power
+			power *= xi
+		}
+		if opts.W != nil {
+			for j := range lhs[i] {
+				lhs[i][j] *= opts.W[i]
+			}
+			rhs[i] *= opts.W[i]
+		}
+	}
+	coeffs, residuals, rank, s, scale, err := scaledLstsq(lhs, rhs, rcond)
+	if err != nil {
+		return PolyfitResult{}, err
+	}
+	result := PolyfitResult{Coefficients: coeffs, Residuals: residuals, Rank: rank, SingularValues: s, Rcond: rcond}
+	if !opts.Cov {
+		return result, nil
+	}
+
+	fac := 1.0
+	if !opts.Unscaled {
+		if len(x) <= order {
+			return PolyfitResult{}, errors.New("polyfit: the number of data points must exceed order to scale the covariance matrix")
+		}
+		if len(residuals) == 0 {
+			return PolyfitResult{}, fmt.Errorf("polyfit: %w: cannot scale the covariance of a rank deficient fit", ErrSingular)
+		}
+		fac = residuals[0] / float64(len(x)-order)
+	}
+	base, err := Inv(Matmul(Transpose(lhs), lhs))
+	if err != nil {
+		return PolyfitResult{}, fmt.Errorf("polyfit: covariance: %w", err)
+	}
+	for i := range base {
+		for j := range base[i] {
+			base[i][j] *= fac / (scale[i] * scale[j])
+		}
+	}
+	result.Cov = base
+	return result, nil
+}
+
+// scaledLstsq solves the least-squares problem lhs x = rhs like Lstsq after scaling the columns of lhs to
+// unit norm, which lhs is left with, and returns the column scales along with the results of Lstsq
+func scaledLstsq(lhs [][]float64, rhs []float64, rcond float64) ([]float64, []float64, int, []float64, []float64, error) {
+	scale := make([]float64, len(lhs[0]))
+	for j := range scale {
+		for i := range lhs {
+			scale[j] += lhs[i][j] * lhs[i][j]
+		}
+		scale[j] = math.Sqrt(scale[j])
+		if scale[j] == 0 {
+			scale[j] = 1
+		}
+		for i := range lhs {
+			lhs[i][j] /= scale[j]
+		}
+	}
+	coeffs, residuals, rank, s, err := Lstsq(lhs, rhs, rcond)
+	if err != nil {
+		return nil, nil, 0, nil, nil, err
+	}
+	for j := range coeffs {
+		coeffs[j] /= scale[j]
+	}
+	return coeffs, residuals, rank, s, scale, nil
+}
+
+// Polyval evaluates the polynomial with coefficients p, highest power first, at each point of x using
+// Horner's scheme.
+func Polyval(p []float64, x []float64) []float64 {
+	result := make([]float64, len(x))
+	for i, xi := range x {
+		for _, c := range p {
+			result[i] = result[i]*xi + c
+		}
+	}
+	return result
+}
+
+// Roots returns the roots of the polynomial with coefficients p, highest power first, computed as the
+// eigenvalues of its companion matrix like numpy.roots. Leading zeros are ignored and trailing zeros give
+// roots at the origin.
+func Roots(p []float64) []complex128 {
+	roots, err := TryRoots(p)
+	if err != nil {
+		panic(err)
+	}
+	return roots
+}
+
+// TryRoots is Roots returning ErrNotConverged instead of panicking
+func TryRoots(p []float64) ([]complex128, error) {
+	for len(p) > 0 && p[0] == 0 {
+		p = p[1:]
+	}
+	// trailing zeros are roots at the origin
+	trailing := 0
+	for len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+		trailing++
+	}
+
+	var roots []complex128
+	degree := len(p) - 1
+	if degree >= 1 {
+		companion := Zeros(degree, degree)
+		for j := 0; j < degree; j++ {
+			companion[0][j] = -p[j+1] / p[0]
+		}
+		for i := 1; i < degree; i++ {
+			companion[i][i-1] = 1
+		}
+		var err error
+		if roots, _, err = eigenNonsymmetric(companion); err != nil {
+			return nil, fmt.Errorf("roots: %w", err)
+		}
+	}
+	for i := 0; i < trailing; i++ {
+		roots = append(roots, 0)
+	}
+	return roots, nil
+}
+
+// Poly returns the coefficients, highest power first, of the monic polynomial with the given roots like
+// numpy.poly. Complex roots should come in conjugate pairs so that the coefficients are real, the
+// imaginary parts left by rounding are dropped.
+func Poly(roots []complex128) []float64 {
+	return Real(polyFromRoots(roots))
+}
+
+// Polyder returns the m-th derivative of the polynomial with coefficients p, highest power first. The
+// derivative of a constant is the zero polynomial [0].
+func Polyder(p []float64, m int) []float64 {
+	if m < 0 {
+		panic("polyder: the order of the derivative must be non-negative")
+	}
+	result := append([]float64{}, p...)
+	for ; m > 0; m-- {
+		n := len(result) - 1
+		if n < 1 {
+			return []float64{0}
+		}
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = result[i] * float64(n-i)
+		}
+		result = next
+	}
+	return result
+}
+
+// Polyint returns the m-th antiderivative of the polynomial with coefficients p, highest power first. k
+// holds the integration constants, k[0] being added by the first integration, and may be nil for zeros.
+func Polyint(p []float64, m int, k []float64) []float64 {
+	if m < 0 {
+		panic("polyint: the order of the integral must be non-negative")
+	}
+	if k != nil && len(k) != m {
+		panic(fmt.Sprintf("polyint: %d integration constants given for an integral of order %d", len(k), m))
+	}
+	result := append([]float64{}, p...)
+	for i := 0; i < m; i++ {
+		n := len(result)
+		next := make([]float64, n+1)
+		for j, c := range result {
+			next[j] = c / float64(n-j)
+		}
+		if k != nil {
+			next[n] = k[i]
+		}
+		result = next
+	}
+	return result
+}
+
+// alignPolys returns copies of a and b padded with leading zeros to the same length
+func alignPolys(a, b []float64) ([]float64, []float64) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	x, y := make([]float64, n), make([]float64, n)
+	copy(x[n-len(a):], a)
+	copy(y[n-len(b):], b)
+	return x, y
+}
+
+// Polyadd returns the sum of two polynomials, highest power first.
+func Polyadd(a, b []float64) []float64 {
+	x, y := alignPolys(a, b)
+	for i := range x {
+		x[i] += y[i]
+	}
+	return x
+}
+
+// Polysub returns the difference a - b of two polynomials, highest power first.
+func Polysub(a, b []float64) []float64 {
+	x, y := alignPolys(a, b)
+	for i := range x {
+		x[i] -= y[i]
+	}
+	return x
+}
+
+// Polymul returns the product of two polynomials, highest power first.
+func Polymul(a, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return []float64{}
+	}
+	result := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			result[i+j] += x * y
+		}
+	}
+	return result
+}
+
+// Polydiv divides the polynomial u by v, highest power first, and returns the quotient and remainder
+// like numpy.polydiv, which drops leading remainder coefficients within 1e-8 of zero.
+func Polydiv(u, v []float64) ([]float64, []float64) {
+	for len(v) > 0 && v[0] == 0 {
+		v = v[1:]
+	}
+	if len(v) == 0 {
+		panic("polydiv: division by the zero polynomial")
+	}
+	m, n := len(u)-1, len(v)-1
+	scale := 1 / v[0]
+	q := make([]float64, int(math.Max(float64(m-n+1), 1)))
+	r := append([]float64{}, u...)
+	for k := 0; k <= m-n; k++ {
+		d := scale * r[k]
+		q[k] = d
+		for j := 0; j <= n; j++ {
+			r[k+j] -= d * v[j]
+		}
+	}
+	for len(r) > 1 && math.Abs(r[0]) <= 1e-8 {
+		r = r[1:]
+	}
+	return q, r
+}
+
+// Poly1D is a polynomial in the power basis like numpy.poly1d, with its coefficients stored highest
+// power first and without leading zeros.
+type Poly1D struct {
+	coeffs []float64
+}
+
+// NewPoly1D returns the polynomial with coefficients given highest power first, copying them.
+func NewPoly1D(coeffs []float64) *Poly1D {
+	for len(coeffs) > 1 && coeffs[0] == 0 {
+		coeffs = coeffs[1:]
+	}
+	if len(coeffs) == 0 {
+		coeffs = []float64{0}
+	}
+	return &Poly1D{coeffs: append([]float64{}, coeffs...)}
+}
+
+// Poly1DFromRoots returns the monic polynomial with the given roots, see Poly.
+func Poly1DFromRoots(roots []complex128) *Poly1D {
+	return NewPoly1D(Poly(roots))
+}
+
+// Coeffs returns a copy of the coefficients, highest power first.
+func (poly *Poly1D) Coeffs() []float64 {
+	return append([]float64{}, poly.coeffs...)
+}
+
+// Order returns the degree of the polynomial.
+func (poly *Poly1D) Order() int {
+	return len(poly.coeffs) - 1
+}
+
+// Eval returns the value of the polynomial at x.
+func (poly *Poly1D) Eval(x float64) float64 {
+	return Polyval(poly.coeffs, []float64{x})[0]
+}
+
+// Roots returns the roots of the polynomial.
+func (poly *Poly1D) Roots() []complex128 {
+	return Roots(poly.coeffs)
+}
+
+// Deriv returns the m-th derivative of the polynomial.
+func (poly *Poly1D) Deriv(m int) *Poly1D {
+	return NewPoly1D(Polyder(poly.coeffs, m))
+}
+
+// Integ returns the m-th antiderivative of the polynomial with the integration constants k, see Polyint.
+func (poly *Poly1D) Integ(m int, k []float64) *Poly1D {
+	return NewPoly1D(Polyint(poly.coeffs, m, k))
+}
+
+// Add returns the sum of the polynomial and other.
+func (poly *Poly1D) Add(other *Poly1D) *Poly1D {
+	return NewPoly1D(Polyadd(poly.coeffs, other.coeffs))
+}
+
+// Sub returns the difference of the polynomial and other.
+func (poly *Poly1D) Sub(other *Poly1D) *Poly1D {
+	return NewPoly1D(Polysub(poly.coeffs, other.coeffs))
+}
+
+// Mul returns the product of the polynomial and other.
+func (poly *Poly1D) Mul(other *Poly1D) *Poly1D {
+	return NewPoly1D(Polymul(poly.coeffs, other.coeffs))
+}
+
+// Div returns the quotient and remainder of the division of the polynomial by other.
+func (poly *Poly1D) Div(other *Poly1D) (*Poly1D, *Poly1D) {
+	q, r := Polydiv(poly.coeffs, other.coeffs)
+	return NewPoly1D(q), NewPoly1D(r)
+}
@@ -0,0 +1,169 @@
+package vectors
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPolyfit(t *testing.T) {
+	expected := []float64{0.64, 0.55}
+	x := []float64{1, 3, 4, 6, 8, 9, 11, 14}
+	y := []float64{1, 2, 4, 4, 5, 7, 8, 9}
+	output := Polyfit(x, y, 1, PolyfitOptions{})
+	if reflect.DeepEqual(expected, Round(output, 2)) != true {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	// a quadratic is recovered exactly, weights do not change an exact fit
+	quadratic := Apply(x, func(v float64) float64 { return 2*v*v - 3*v + 1 })
+	weights := []float64{1, 2, 1, 2, 1, 2, 1, 2}
+	output = Polyfit(x, quadratic, 2, PolyfitOptions{W: weights})
+	if !AllClose([]float64{2, -3, 1}, output, 1e-9) {
+		t.Errorf("Got %v, want [2 -3 1]", output)
+	}
+
+	if _, err := TryPolyfit([]float64{2, 2}, []float64{1, 3}, 1, PolyfitOptions{}); !errors.Is(err, ErrSingular) {
+		t.Errorf("TryPolyfit = %v, want %v", err, ErrSingular)
+	}
+	if _, err := TryPolyfit(x, y[:3], 1, PolyfitOptions{}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("TryPolyfit = %v, want %v", err, ErrShapeMismatch)
+	}
+}
+
+func TestPolyfitFull(t *testing.T) {
+	x := []float64{1, 3, 4, 6, 8, 9, 11, 14}
+	y := []float64{1, 2, 4, 4, 5, 7, 8, 9}
+	result, err := PolyfitFull(x, y, 1, PolyfitOptions{Cov: true})
+	if err != nil {
+		t.Fatalf("PolyfitFull returned error %v", err)
+	}
+	if result.Rank != 2 || len(result.SingularValues) != 2 || result.Rcond != 8*2.220446049250313e-16 {
+		t.Errorf("Got rank %d, singular values %v and rcond %v", result.Rank, result.SingularValues, result.Rcond)
+	}
+	if len(result.Residuals) != 1 || !AllClose([]float64{2.5454545454545436}, result.Residuals, 1e-9) {
+		t.Errorf("Got residuals %v, want [2.5454545454545436]", result.Residuals)
+	}
+	expected := [][]float64{{0.0032139577594123025, -0.022497704315886116}, {-0.022497704315886116, 0.2105142332415058}}
+	if !matricesClose(expected, result.Cov, 1e-12) {
+		t.Errorf("Got cov %v, want %v", result.Cov, expected)
+	}
+
+	result, err = PolyfitFull(x, y, 1, PolyfitOptions{Cov: true, Unscaled: true})
+	expected = [][]float64{{0.007575757575757576, -0.05303030303030303}, {-0.05303030303030303, 0.4962121212121212}}
+	if err != nil || !matricesClose(expected, result.Cov, 1e-12) {
+		t.Errorf("Got cov %v and error %v, want %v", result.Cov, err, expected)
+	}
+
+	// a rank deficient fit is reported instead of failing
+	result, err = PolyfitFull([]float64{2, 2, 2}, []float64{1, 2, 3}, 1, PolyfitOptions{})
+	if err != nil || result.Rank != 1 || result.Residuals != nil {
+		t.Errorf("Got rank %d, residuals %v and error %v", result.Rank, result.Residuals, err)
+	}
+	if _, err := PolyfitFull([]float64{1, 2}, []float64{1, 2}, 1, PolyfitOptions{Cov: true}); err == nil {
+		t.Errorf("PolyfitFull scaled the covariance of an exactly determined fit")
+	}
+}
+
+func TestPolyval(t *testing.T) {
+	expected := []float64{1, 76, 28}
+	if output := Polyval([]float64{3, 0, 1}, []float64{0, 5, -3}); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := Polyval(nil, []float64{2}); !reflect.DeepEqual([]float64{0}, output) {
+		t.Errorf("Got %v, want [0]", output)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	roots := Real(Roots([]float64{0, 1, -6, 11, -6}))
+	sort.Float64s(roots)
+	if !AllClose([]float64{1, 2, 3}, roots, 1e-10) {
+		t.Errorf("Got %v, want [1 2 3]", roots)
+	}
+	// x^2 + 1 has a conjugate pair of roots and trailing zeros are roots at the origin
+	roots2 := Roots([]float64{1, 0, 1, 0, 0})
+	if len(roots2) != 4 || cmplxAbs(roots2[0]-1i)*cmplxAbs(roots2[1]-1i) > 1e-10 || roots2[2] != 0 || roots2[3] != 0 {
+		t.Errorf("Got %v", roots2)
+	}
+
+	expected := []float64{1, -6, 11, -6}
+	if output := Poly([]complex128{1, 2, 3}); !AllClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := Poly([]complex128{1i, -1i}); !reflect.DeepEqual([]float64{1, 0, 1}, output) {
+		t.Errorf("Got %v, want [1 0 1]", output)
+	}
+}
+
+func TestPolyder(t *testing.T) {
+	expected := []float64{3, 2, 1}
+	if output := Polyder([]float64{1, 1, 1, 1}, 1); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := Polyder([]float64{1, 1, 1, 1}, 2); !reflect.DeepEqual([]float64{6, 2}, output) {
+		t.Errorf("Got %v, want [6 2]", output)
+	}
+	if output := Polyder([]float64{1, 1}, 3); !reflect.DeepEqual([]float64{0}, output) {
+		t.Errorf("Got %v, want [0]", output)
+	}
+}
+
+func TestPolyint(t *testing.T) {
+	expected := []float64{1, 1, 1, 1}
+	if output := Polyint([]float64{3, 2, 1}, 1, []float64{1}); !reflect.DeepEqual(expected, output) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	expected = []float64{1.0 / 12, 2.0 / 3, 1.5, 2, 3}
+	if output := Polyint([]float64{1, 4, 3}, 2, []float64{2, 3}); !AllClose(expected, output, 1e-15) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestPolyArithmetic(t *testing.T) {
+	if output := Polyadd([]float64{1, 2}, []float64{9, 5, 4}); !reflect.DeepEqual([]float64{9, 6, 6}, output) {
+		t.Errorf("Got %v, want [9 6 6]", output)
+	}
+	if output := Polysub([]float64{1, 2}, []float64{9, 5, 4}); !reflect.DeepEqual([]float64{-9, -4, -2}, output) {
+		t.Errorf("Got %v, want [-9 -4 -2]", output)
+	}
+	if output := Polymul([]float64{1, 2, 3}, []float64{9, 5, 1}); !reflect.DeepEqual([]float64{9, 23, 38, 17, 3}, output) {
+		t.Errorf("Got %v, want [9 23 38 17 3]", output)
+	}
+	q, r := Polydiv([]float64{3, 5, 2}, []float64{2, 1})
+	if !reflect.DeepEqual([]float64{1.5, 1.75}, q) || !reflect.DeepEqual([]float64{0.25}, r) {
+		t.Errorf("Got %v and %v, want [1.5 1.75] and [0.25]", q, r)
+	}
+	q, r = Polydiv([]float64{1, -6, 11, -6}, []float64{1, -1})
+	if !reflect.DeepEqual([]float64{1, -5, 6}, q) || !reflect.DeepEqual([]float64{0}, r) {
+		t.Errorf("Got %v and %v, want [1 -5 6] and [0]", q, r)
+	}
+}
+
+func TestPoly1D(t *testing.T) {
+	poly := NewPoly1D([]float64{0, 0, 1, -3, 2})
+	if poly.Order() != 2 || poly.Eval(3) != 2 || !reflect.DeepEqual([]float64{1, -3, 2}, poly.Coeffs()) {
+		t.Errorf("Got order %d, value %v and coefficients %v", poly.Order(), poly.Eval(3), poly.Coeffs())
+	}
+	fromRoots := Poly1DFromRoots([]complex128{1, 2})
+	if !AllClose(poly.Coeffs(), fromRoots.Coeffs(), 1e-12) {
+		t.Errorf("Got %v, want %v", fromRoots.Coeffs(), poly.Coeffs())
+	}
+
+	// the difference of equal polynomials is the zero polynomial of order 0
+	if output := poly.Sub(poly); output.Order() != 0 || output.Eval(7) != 0 {
+		t.Errorf("Got %v", output.Coeffs())
+	}
+	product := poly.Mul(NewPoly1D([]float64{1, -3}))
+	q, r := product.Div(poly)
+	if !reflect.DeepEqual([]float64{1, -3}, q.Coeffs()) || !reflect.DeepEqual([]float64{0}, r.Coeffs()) {
+		t.Errorf("Got %v and %v", q.Coeffs(), r.Coeffs())
+	}
+	if output := poly.Deriv(1).Integ(1, []float64{2}); !reflect.DeepEqual(poly.Coeffs(), output.Coeffs()) {
+		t.Errorf("Got %v, want %v", output.Coeffs(), poly.Coeffs())
+	}
+	if output := poly.Add(NewPoly1D([]float64{-1, 3, -2})); output.Order() != 0 {
+		t.Errorf("Got %v", output.Coeffs())
+	}
+}
@@ -0,0 +1,262 @@
+package vectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNoBackend is returned by NewRemote and by the methods of a Remote without a Backend, and by an
+// HTTPBackend without a BaseURL. There is no default service, its address must always be given.
+var ErrNoBackend = errors.New("remote: no backend configured")
+
+// Backend posts a JSON request to an endpoint of a scipy service and decodes the JSON response into
+// response, which must be a pointer.
+type Backend interface {
+	Post(ctx context.Context, endpoint string, request any, response any) error
+}
+
+// StatusError is returned by HTTPBackend when the service answers with a status other than 200.
+type StatusError struct {
+	Endpoint   string
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("remote: %s returned %s", err.Endpoint, err.Status)
+}
+
+// HTTPBackend is a Backend that posts to a scipy service over HTTP.
+type HTTPBackend struct {
+	// BaseURL is the address of the service, the endpoint name is appended as the path.
+	BaseURL string
+	// Client sends the requests, http.DefaultClient is used when nil.
+	Client *http.Client
+	// Timeout bounds each attempt, no limit is applied when zero.
+	Timeout time.Duration
+	// Retries is the number of additional attempts after a network error or a 5xx status.
+	Retries int
+	// RetryDelay is the pause before the first retry, doubling after each further attempt.
+	RetryDelay time.Duration
+}
+
+// NewHTTPBackend returns an HTTPBackend for the service at baseURL with a 30 second timeout and two
+// retries.
+func NewHTTPBackend(baseURL string) *HTTPBackend {
+	return &HTTPBackend{
+		BaseURL:    baseURL,
+		Timeout:    30 * time.Second,
+		Retries:    2,
+		RetryDelay: 100 * time.Millisecond,
+	}
+}
+
+// Post implements Backend.
+func (backend *HTTPBackend) Post(ctx context.Context, endpoint string, request any, response any) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("remote: encoding the %s request: %w", endpoint, err)
+	}
+	if backend.BaseURL == "" {
+		return fmt.Errorf("%w: HTTPBackend needs a BaseURL", ErrNoBackend)
+	}
+	url := strings.TrimRight(backend.BaseURL, "/") + "/" + endpoint
+
+	delay := backend.RetryDelay
+	for attempt := 0; ; attempt++ {
+		body, retry, err := backend.post(ctx, url, endpoint, payload)
+		if err == nil {
+			if err := json.Unmarshal(body, response); err != nil {
+				return fmt.Errorf("remote: decoding the %s response: %w", endpoint, err)
+			}
+			return nil
+		}
+		if !retry || attempt >= backend.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes a single attempt and reports whether a failure is worth retrying
+func (backend *HTTPBackend) post(ctx context.Context, url, endpoint string, payload []byte) ([]byte, bool, error) {
+	if backend.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, backend.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, fmt.Errorf("remote: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := backend.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		// a cancelled caller is final, a timed out attempt or a network failure may be retried
+		return nil, !errors.Is(err, context.Canceled), fmt.Errorf("remote: %s: %w", endpoint, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("remote: reading the %s response: %w", endpoint, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		statusErr := &StatusError{Endpoint: endpoint, StatusCode: res.StatusCode, Status: res.Status, Body: body}
+		return nil, res.StatusCode >= 500, statusErr
+	}
+	return body, false, nil
+}
+
+// Remote calls the endpoints of a scipy service through a Backend. The native functions of the package
+// do not need it, it is kept to compare against and migrate from the service.
+type Remote struct {
+	Backend Backend
+}
+
+// NewRemote returns a Remote using backend, which must not be nil. Use NewHTTPBackend with the address
+// of the service to call it over HTTP.
+func NewRemote(backend Backend) (*Remote, error) {
+	if backend == nil {
+		return nil, ErrNoBackend
+	}
+	return &Remote{Backend: backend}, nil
+}
+
+// post forwards a request to the backend, failing when the Remote has none
+func (remote *Remote) post(ctx context.Context, endpoint string, request any, response any) error {
+	if remote == nil || remote.Backend == nil {
+		return ErrNoBackend
+	}
+	return remote.Backend.Post(ctx, endpoint, request, response)
+}
+
+type filterDesignRequest struct {
+	Wn          []float64 `json:"Wn"`
+	FilterOrder int       `json:"N"`
+	FilterType  string    `json:"btype"`
+}
+
+type transferFunctionResponse struct {
+	B []float64 `json:"b"`
+	A []float64 `json:"a"`
+}
+
+// design posts a filter design request to one of the butter, cheby1 and bessel endpoints
+func (remote *Remote) design(ctx context.Context, endpoint string, Wn []float64, filterOrder int, filterType string) ([]float64, []float64, error) {
+	var response transferFunctionResponse
+	request := filterDesignRequest{Wn: Wn, FilterOrder: filterOrder, FilterType: filterType}
+	if err := remote.post(ctx, endpoint, request, &response); err != nil {
+		return nil, nil, err
+	}
+	return response.B, response.A, nil
+}
+
+// Butterworth designs a Butterworth filter on the service and returns its transfer function.
+func (remote *Remote) Butterworth(ctx context.Context, Wn []float64, filterOrder int, filterType string) ([]float64, []float64, error) {
+	return remote.design(ctx, "butter", Wn, filterOrder, filterType)
+}
+
+// Cheby1 designs a Chebyshev type I filter with the service's default ripple and returns its transfer
+// function.
+func (remote *Remote) Cheby1(ctx context.Context, Wn []float64, filterOrder int, filterType string) ([]float64, []float64, error) {
+	return remote.design(ctx, "cheby1", Wn, filterOrder, filterType)
+}
+
+// Bessel designs a Bessel filter on the service and returns its transfer function.
+func (remote *Remote) Bessel(ctx context.Context, Wn []float64, filterOrder int, filterType string) ([]float64, []float64, error) {
+	return remote.design(ctx, "bessel", Wn, filterOrder, filterType)
+}
+
+// Filter filters data with the transfer function b, a on the service.
+func (remote *Remote) Filter(ctx context.Context, b []float64, a []float64, data []float64) ([]float64, error) {
+	type requestData struct {
+		B []float64 `json:"b"`
+		A []float64 `json:"a"`
+		X []float64 `json:"x"`
+	}
+	type responseData struct {
+		Y []float64 `json:"y"`
+	}
+	var response responseData
+	if err := remote.post(ctx, "lfilter", requestData{B: b, A: a, X: data}, &response); err != nil {
+		return nil, err
+	}
+	return response.Y, nil
+}
+
+// Interp1D linearly interpolates the samples x and y at the points xi on the service.
+func (remote *Remote) Interp1D(ctx context.Context, x, y, xi []float64) ([]float64, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("interp1d: %w: x and y must have the same length", ErrShapeMismatch)
+	}
+	type requestData struct {
+		X  []float64 `json:"x"`
+		Xi []float64 `json:"xi"`
+		Y  []float64 `json:"y"`
+	}
+	type responseData struct {
+		Y []float64 `json:"y"`
+	}
+	var response responseData
+	if err := remote.post(ctx, "interpolate", requestData{X: x, Xi: xi, Y: y}, &response); err != nil {
+		return nil, err
+	}
+	return response.Y, nil
+}
+
+// CurveFit fits the service's model to x and y starting from p0 within bounds, returning the optimal
+// parameters and their covariance.
+func (remote *Remote) CurveFit(ctx context.Context, x, y, p0 []float64, bounds [][]float64) ([]float64, [][]float64, error) {
+	if len(x) != len(y) {
+		return nil, nil, fmt.Errorf("curve_fit: %w: x and y must have the same length", ErrShapeMismatch)
+	}
+	type requestData struct {
+		X      []float64   `json:"x"`
+		Y      []float64   `json:"y"`
+		P0     []float64   `json:"p0"`
+		Bounds [][]float64 `json:"bounds"`
+	}
+	type responseData struct {
+		Popt  []float64   `json:"popt"`
+		Pconv [][]float64 `json:"pconv"`
+	}
+	var response responseData
+	if err := remote.post(ctx, "curve_fit", requestData{X: x, Y: y, P0: p0, Bounds: bounds}, &response); err != nil {
+		return nil, nil, err
+	}
+	return response.Popt, response.Pconv, nil
+}
+
+// FSolve solves the service's system of equations from x0, returning the root and the ier flag.
+func (remote *Remote) FSolve(ctx context.Context, x0 []float64) ([]float64, int, error) {
+	type requestData struct {
+		X0 []float64 `json:"x0"`
+	}
+	type responseData struct {
+		X   []float64 `json:"X"`
+		Ier float64   `json:"ier"`
+	}
+	var response responseData
+	if err := remote.post(ctx, "fsolve", requestData{X0: x0}, &response); err != nil {
+		return nil, 0, err
+	}
+	return response.X, int(response.Ier), nil
+}
@@ -0,0 +1,138 @@
+package vectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPBackend(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case "/lfilter":
+			var request map[string][]float64
+			json.NewDecoder(r.Body).Decode(&request)
+			json.NewEncoder(w).Encode(map[string][]float64{"y": request["x"]})
+		case "/flaky":
+			if n%2 == 1 {
+				http.Error(w, "busy", http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"y": [1]}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	backend := NewHTTPBackend(server.URL + "/")
+	backend.RetryDelay = time.Millisecond
+	remote, err := NewRemote(backend)
+	if err != nil {
+		t.Fatalf("NewRemote returned error %v", err)
+	}
+	output, err := remote.Filter(context.Background(), []float64{1}, []float64{1}, []float64{1, 2, 3})
+	if err != nil || !reflect.DeepEqual([]float64{1, 2, 3}, output) {
+		t.Errorf("Got %v, %v, want %v", output, err, []float64{1, 2, 3})
+	}
+
+	// server errors are retried, client errors are not
+	atomic.StoreInt32(&calls, 0)
+	var response struct{ Y []float64 }
+	if err := backend.Post(context.Background(), "flaky", nil, &response); err != nil || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Post(flaky) = %v after %d calls, want success after 2", err, calls)
+	}
+	atomic.StoreInt32(&calls, 0)
+	err = backend.Post(context.Background(), "missing", nil, &response)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Post(missing) = %v after %d calls, want a 404 StatusError after 1", err, calls)
+	}
+
+	backend.Timeout = 20 * time.Millisecond
+	backend.Retries = 0
+	if err := backend.Post(context.Background(), "slow", nil, &response); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Post(slow) = %v, want a deadline error", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := backend.Post(ctx, "lfilter", nil, &response); !errors.Is(err, context.Canceled) {
+		t.Errorf("Post with a cancelled context = %v, want a cancellation error", err)
+	}
+
+	// a network failure is returned instead of panicking
+	server.Close()
+	if _, _, err := remote.FSolve(context.Background(), []float64{1}); err == nil {
+		t.Errorf("FSolve on a closed server returned no error")
+	}
+
+	// there is no default address to fall back on
+	if err := NewHTTPBackend("").Post(context.Background(), "lfilter", nil, &response); !errors.Is(err, ErrNoBackend) {
+		t.Errorf("Post without a base URL = %v, want ErrNoBackend", err)
+	}
+}
+
+type stubBackend map[string]string
+
+func (stub stubBackend) Post(ctx context.Context, endpoint string, request any, response any) error {
+	body, ok := stub[endpoint]
+	if !ok {
+		return errors.New("unknown endpoint " + endpoint)
+	}
+	return json.Unmarshal([]byte(body), response)
+}
+
+func TestRemote(t *testing.T) {
+	remote, err := NewRemote(stubBackend{
+		"butter":      `{"b": [1, 2], "a": [1, 0.5]}`,
+		"interpolate": `{"y": [1.5]}`,
+		"curve_fit":   `{"popt": [2], "pconv": [[0.1]]}`,
+		"fsolve":      `{"X": [0.5], "ier": 1}`,
+	})
+	if err != nil {
+		t.Fatalf("NewRemote returned error %v", err)
+	}
+	ctx := context.Background()
+
+	b, a, err := remote.Butterworth(ctx, []float64{0.2}, 1, "lowpass")
+	if err != nil || !reflect.DeepEqual([]float64{1, 2}, b) || !reflect.DeepEqual([]float64{1, 0.5}, a) {
+		t.Errorf("Butterworth = %v, %v, %v", b, a, err)
+	}
+	if _, _, err := remote.Cheby1(ctx, []float64{0.2}, 1, "lowpass"); err == nil {
+		t.Errorf("Cheby1 on a backend without the endpoint returned no error")
+	}
+	y, err := remote.Interp1D(ctx, []float64{1, 2}, []float64{1, 2}, []float64{1.5})
+	if err != nil || !reflect.DeepEqual([]float64{1.5}, y) {
+		t.Errorf("Interp1D = %v, %v", y, err)
+	}
+	if _, err := remote.Interp1D(ctx, []float64{1, 2}, []float64{1}, nil); err == nil {
+		t.Errorf("Interp1D with mismatched lengths returned no error")
+	}
+	popt, pcov, err := remote.CurveFit(ctx, []float64{1}, []float64{2}, []float64{1}, nil)
+	if err != nil || !reflect.DeepEqual([]float64{2}, popt) || !reflect.DeepEqual([][]float64{{0.1}}, pcov) {
+		t.Errorf("CurveFit = %v, %v, %v", popt, pcov, err)
+	}
+	x, ier, err := remote.FSolve(ctx, []float64{1})
+	if err != nil || ier != 1 || !reflect.DeepEqual([]float64{0.5}, x) {
+		t.Errorf("FSolve = %v, %v, %v", x, ier, err)
+	}
+
+	// a Remote needs an explicit backend
+	if _, err := NewRemote(nil); !errors.Is(err, ErrNoBackend) {
+		t.Errorf("NewRemote(nil) returned %v, want ErrNoBackend", err)
+	}
+	if _, _, err := (&Remote{}).Butterworth(ctx, []float64{0.2}, 1, "lowpass"); !errors.Is(err, ErrNoBackend) {
+		t.Errorf("Butterworth on the zero Remote returned %v, want ErrNoBackend", err)
+	}
+}
@@ -0,0 +1,363 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// ButterworthSOS designs an Nth-order digital Butterworth filter and returns it as second-order sections.
+func ButterworthSOS(Wn []float64, filterOrder int, filterType string) [][]float64 {
+	return IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{Output: "sos"}).SOS
+}
+
+// Cheby1SOS designs an Nth-order digital Chebyshev type I filter and returns it as second-order sections.
+func Cheby1SOS(Wn []float64, filterOrder int, rp float64, filterType string) [][]float64 {
+	return IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby1", Rp: rp, Output: "sos"}).SOS
+}
+
+// Cheby2SOS designs an Nth-order digital Chebyshev type II filter and returns it as second-order sections.
+func Cheby2SOS(Wn []float64, filterOrder int, rs float64, filterType string) [][]float64 {
+	return IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "cheby2", Rs: rs, Output: "sos"}).SOS
+}
+
+// BesselSOS designs an Nth-order digital Bessel/Thomson filter and returns it as second-order sections.
+func BesselSOS(Wn []float64, filterOrder int, filterType string, norm string) [][]float64 {
+	return IIRFilter(Wn, filterOrder, filterType, IIRFilterOptions{FType: "bessel", Norm: norm, Output: "sos"}).SOS
+}
+
+// Tf2Zpk returns the zeros, poles and gain of the filter with numerator b and denominator a.
+func Tf2Zpk(b []float64, a []float64) ([]complex128, []complex128, float64) {
+	for len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(a) == 0 || a[0] == 0 {
+		panic("tf2zpk: first element of a must be nonzero")
+	}
+	k := b[0] / a[0]
+	if k == 0 {
+		return nil, Roots(a), 0
+	}
+	return Roots(b), Roots(a), k
+}
+
+// Tf2Sos converts the filter with numerator b and denominator a to second-order sections.
+func Tf2Sos(b []float64, a []float64) [][]float64 {
+	return Zpk2Sos(Tf2Zpk(b, a))
+}
+
+// Sos2Tf returns the numerator (b) and denominator (a) coefficients of a cascade of second-order sections.
+func Sos2Tf(sos [][]float64) ([]float64, []float64) {
+	validateSos("sos2tf", sos)
+	b := []float64{1}
+	a := []float64{1}
+	for _, section := range sos {
+		b = Polymul(b, section[:3])
+		a = Polymul(a, section[3:])
+	}
+	return b, a
+}
+
+// Zpk2Sos converts a digital filter given by its zeros, poles and gain to second-order sections,
+// pairing each pole with its nearest zero and ordering the sections so that the poles closest to the
+// unit circle come last.
+func Zpk2Sos(z, p []complex128, k float64) [][]float64 {
+	if len(z) == 0 && len(p) == 0 {
+		return [][]float64{{k, 0, 0, 1, 0, 0}}
+	}
+
+	// ensure the same number of poles and zeros and an even count for the odd real pole
+	z = append([]complex128{}, z...)
+	p = append([]complex128{}, p...)
+	for len(p) < len(z) {
+		p = append(p, 0)
+	}
+	for len(z) < len(p) {
+		z = append(z, 0)
+	}
+	nSections := (len(p) + 1) / 2
+	if len(p)%2 == 1 {
+		p = append(p, 0)
+		z = append(z, 0)
+	}
+
+	// keep one member of each conjugate pair followed by the real values
+	zc, zr := cplxReal("zpk2sos", z)
+	z = append(zc, zr...)
+	pc, pr := cplxReal("zpk2sos", p)
+	p = append(pc, pr...)
+
+	// the "worst" pole is the one closest to the unit circle
+	idxWorst := func(values []complex128) int {
+		best := 0
+		for i, v := range values {
+			if math.Abs(1-cmplx.Abs(v)) < math.Abs(1-cmplx.Abs(values[best])) {
+				best = i
+			}
+		}
+		return best
+	}
+	countReal := func(values []complex128) int {
+		count := 0
+		for _, v := range values {
+			if imag(v) == 0 {
+				count++
+			}
+		}
+		return count
+	}
+	remove := func(values []complex128, index int) []complex128 {
+		return append(values[:index:index], values[index+1:]...)
+	}
+
+	sos := make([][]float64, nSections)
+	for si := nSections - 1; si >= 0; si-- {
+		p1Idx := idxWorst(p)
+		p1 := p[p1Idx]
+		p = remove(p, p1Idx)
+
+		switch {
+		case imag(p1) == 0 && countReal(p) == 0:
+			// the last remaining real pole is paired with the nearest real zero
+			z1Idx := nearestRealComplexIdx(z, p1, "real")
+			z1 := z[z1Idx]
+			z = remove(z, z1Idx)
+			sos[si] = singleZpkSos([]complex128{z1, 0}, []complex128{p1, 0})
+		case len(p)+1 == len(z) && imag(p1) != 0 && countReal(p) == 1 && countReal(z) == 1:
+			// one real pole and one real zero are left, so this pair must take a complex zero
+			z1Idx := nearestRealComplexIdx(z, p1, "complex")
+			z1 := z[z1Idx]
+			z = remove(z, z1Idx)
+			sos[si] = singleZpkSos([]complex128{z1, cmplx.Conj(z1)}, []complex128{p1, cmplx.Conj(p1)})
+		default:
+			var p2 complex128
+			if imag(p1) == 0 {
+				var realIdx []int
+				var realPoles []complex128
+				for i, v := range p {
+					if imag(v) == 0 {
+						realIdx = append(realIdx, i)
+						realPoles = append(realPoles, v)
+					}
+				}
+				p2Idx := realIdx[idxWorst(realPoles)]
+				p2 = p[p2Idx]
+				p = remove(p, p2Idx)
+			} else {
+				p2 = cmplx.Conj(p1)
+			}
+
+			if len(z) == 0 {
+				sos[si] = singleZpkSos(nil, []complex128{p1, p2})
+				continue
+			}
+			z1Idx := nearestRealComplexIdx(z, p1, "any")
+			z1 := z[z1Idx]
+			z = remove(z, z1Idx)
+			if imag(z1) != 0 {
+				sos[si] = singleZpkSos([]complex128{z1, cmplx.Conj(z1)}, []complex128{p1, p2})
+			} else if len(z) > 0 {
+				z2Idx := nearestRealComplexIdx(z, p1, "real")
+				z2 := z[z2Idx]
+				z = remove(z, z2Idx)
+				sos[si] = singleZpkSos([]complex128{z1, z2}, []complex128{p1, p2})
+			} else {
+				sos[si] = singleZpkSos([]complex128{z1}, []complex128{p1, p2})
+			}
+		}
+	}
+
+	// put the gain in the first section
+	for i := 0; i < 3; i++ {
+		sos[0][i] *= k
+	}
+	return sos
+}
+
+// cplxReal splits values into one member (positive imaginary part) of each complex conjugate pair and
+// the real values, both sorted by their real parts
+func cplxReal(name string, values []complex128) ([]complex128, []complex128) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tol := 100 * 2.220446049250313e-16
+	sorted := append([]complex128{}, values...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if real(sorted[i]) != real(sorted[j]) {
+			return real(sorted[i]) < real(sorted[j])
+		}
+		return math.Abs(imag(sorted[i])) < math.Abs(imag(sorted[j]))
+	})
+
+	var zr, zp, zn []complex128
+	for _, v := range sorted {
+		switch {
+		case math.Abs(imag(v)) <= tol*cmplx.Abs(v):
+			zr = append(zr, complex(real(v), 0))
+		case imag(v) > 0:
+			zp = append(zp, v)
+		default:
+			zn = append(zn, v)
+		}
+	}
+	if len(zp) != len(zn) {
+		panic(fmt.Sprintf("%s: array contains complex value with no matching conjugate", name))
+	}
+
+	// sort runs of (approximately) equal real parts by their imaginary parts
+	for start := 0; start < len(zp); {
+		stop := start + 1
+		for stop < len(zp) && real(zp[stop])-real(zp[stop-1]) <= tol*cmplx.Abs(zp[stop-1]) {
+			stop++
+		}
+		for _, chunk := range [][]complex128{zp[start:stop], zn[start:stop]} {
+			sort.SliceStable(chunk, func(i, j int) bool {
+				return math.Abs(imag(chunk[i])) < math.Abs(imag(chunk[j]))
+			})
+		}
+		start = stop
+	}
+
+	zc := make([]complex128, len(zp))
+	for i := range zp {
+		if cmplx.Abs(zp[i]-cmplx.Conj(zn[i])) > tol*cmplx.Abs(zn[i]) {
+			panic(fmt.Sprintf("%s: array contains complex value with no matching conjugate", name))
+		}
+		// average out numerical inaccuracy in the real and imaginary parts of the pair
+		zc[i] = (zp[i] + cmplx.Conj(zn[i])) / 2
+	}
+	return zc, zr
+}
+
+// nearestRealComplexIdx returns the index of the value in from closest to target that is real, complex or any
+func nearestRealComplexIdx(from []complex128, target complex128, which string) int {
+	order := make([]int, len(from))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return cmplx.Abs(from[order[i]]-target) < cmplx.Abs(from[order[j]]-target)
+	})
+	for _, i := range order {
+		isReal := imag(from[i]) == 0
+		if which == "any" || (which == "real" && isReal) || (which == "complex" && !isReal) {
+			return i
+		}
+	}
+	panic("zpk2sos: no zero of the requested kind is left to pair")
+}
+
+// singleZpkSos returns the second-order section with up to two zeros and poles and unit gain
+func singleZpkSos(z, p []complex128) []float64 {
+	section := make([]float64, 6)
+	b, a := zpk2tf(z, p, 1)
+	copy(section[3-len(b):3], b)
+	copy(section[6-len(a):6], a)
+	return section
+}
+
+// validateSos panics unless every section has six coefficients with a leading denominator coefficient of one
+func validateSos(name string, sos [][]float64) {
+	if len(sos) == 0 {
+		panic(fmt.Sprintf("%s: sos must contain at least one section", name))
+	}
+	for _, section := range sos {
+		if len(section) != 6 {
+			panic(fmt.Sprintf("%s: each section must have six coefficients", name))
+		}
+		if section[3] != 1 {
+			panic(fmt.Sprintf("%s: the fourth coefficient of each section must be one", name))
+		}
+	}
+}
+
+// SosFilt filters data with a cascade of second-order sections, starting from zero initial conditions.
+func SosFilt(sos [][]float64, data []float64) []float64 {
+	y, _ := SosFiltWithState(sos, data, nil)
+	return y
+}
+
+// SosFiltWithState filters data with a cascade of second-order sections. zi holds two delay values for
+// each section, or is nil for zero initial conditions. The filtered signal and final delay values are returned.
+func SosFiltWithState(sos [][]float64, data []float64, zi [][]float64) ([]float64, [][]float64) {
+	validateSos("sosfilt", sos)
+	if zi != nil && len(zi) != len(sos) {
+		panic(fmt.Sprintf("sosfilt: zi must have %d sections", len(sos)))
+	}
+	zf := make([][]float64, len(sos))
+	y := append([]float64{}, data...)
+	for s, section := range sos {
+		var state []float64
+		if zi != nil {
+			state = zi[s]
+		}
+		y, zf[s] = FilterWithState(section[:3], section[3:], y, state)
+	}
+	return y, zf
+}
+
+// SosFiltZi returns the initial delay values of SosFiltWithState that correspond to the steady state
+// of the step response.
+func SosFiltZi(sos [][]float64) [][]float64 {
+	validateSos("sosfilt_zi", sos)
+	zi := make([][]float64, len(sos))
+	scale := 1.0
+	for s, section := range sos {
+		b, a := section[:3], section[3:]
+		zi[s] = MultiplyBy(LfilterZi(b, a), scale)
+		// the steady state of this section's step response is its gain at DC
+		scale *= Sum(b) / Sum(a)
+	}
+	return zi
+}
+
+// SosFiltFilt applies a cascade of second-order sections forward and backward to data, giving a
+// zero-phase output with the default odd padding.
+func SosFiltFilt(sos [][]float64, data []float64) []float64 {
+	return SosFiltFiltWith(sos, data, FiltFiltOptions{})
+}
+
+// SosFiltFiltWith applies a cascade of second-order sections forward and backward to data using the
+// padding selected by opts. Only the "pad" method is supported.
+func SosFiltFiltWith(sos [][]float64, data []float64, opts FiltFiltOptions) []float64 {
+	validateSos("sosfiltfilt", sos)
+	if opts.Method != "" && opts.Method != "pad" {
+		panic(fmt.Sprintf("sosfiltfilt: unsupported method %q", opts.Method))
+	}
+	if len(data) == 0 {
+		return []float64{}
+	}
+
+	edge := opts.PadLen
+	switch {
+	case opts.PadType == "none":
+		edge = 0
+	case edge == 0:
+		// sections with trailing zero coefficients do not add to the number of taps
+		zerosB, zerosA := 0, 0
+		for _, section := range sos {
+			if section[2] == 0 {
+				zerosB++
+			}
+			if section[5] == 0 {
+				zerosA++
+			}
+		}
+		edge = 3 * (2*len(sos) + 1 - int(math.Min(float64(zerosB), float64(zerosA))))
+	}
+	ext := padSignal("sosfiltfilt", data, opts.PadType, edge)
+
+	zi := SosFiltZi(sos)
+	scaled := func(scale float64) [][]float64 {
+		var result [][]float64
+		for _, row := range zi {
+			result = append(result, MultiplyBy(row, scale))
+		}
+		return result
+	}
+	y, _ := SosFiltWithState(sos, ext, scaled(ext[0]))
+	y, _ = SosFiltWithState(sos, Flipud(y), scaled(y[len(y)-1]))
+	y = Flipud(y)
+	return y[edge : len(y)-edge]
+}
@@ -0,0 +1,114 @@
+package vectors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSos2Tf(t *testing.T) {
+	sos := [][]float64{{1, 1, 0, 1, -0.5, 0}, {1, 0, -1, 1, 0, 0.25}}
+	expectedB := []float64{1, 1, -1, -1, 0}
+	expectedA := []float64{1, -0.5, 0.25, -0.125, 0}
+	b, a := Sos2Tf(sos)
+	if !AllClose(expectedB, b, 1e-12) || !AllClose(expectedA, a, 1e-12) {
+		t.Errorf("Got %v, %v, want %v, %v", b, a, expectedB, expectedA)
+	}
+}
+
+func TestZpk2Sos(t *testing.T) {
+	for _, order := range []int{1, 2, 5, 8} {
+		for _, btype := range []string{"lowpass", "bandpass"} {
+			Wn := []float64{0.3}
+			if btype == "bandpass" {
+				Wn = []float64{0.2, 0.4}
+			}
+			sos := ButterworthSOS(Wn, order, btype)
+			expectedB, expectedA := Butterworth(Wn, order, btype)
+			b, a := Sos2Tf(sos)
+			n := len(expectedA)
+			if !AllClose(expectedB, b[:n], 1e-10) || !AllClose(expectedA, a[:n], 1e-10) {
+				t.Errorf("Sos2Tf(ButterworthSOS(%v,%d,%s)) = %v, %v, want %v, %v", Wn, order, btype, b, a, expectedB, expectedA)
+			}
+		}
+	}
+
+	// the sections are ordered with the poles closest to the unit circle last
+	sos := Zpk2Sos(ButterworthZPK([]float64{0.2, 0.4}, 4, "bandpass"))
+	if len(sos) != 4 {
+		t.Fatalf("Zpk2Sos returned %d sections, want 4", len(sos))
+	}
+	for i := 1; i < len(sos); i++ {
+		if sos[i][5] < sos[i-1][5] {
+			t.Errorf("section %d has a smaller pole radius than section %d: %v", i, i-1, sos)
+		}
+	}
+
+	expected := [][]float64{{2, 0, 0, 1, 0, 0}}
+	output := Zpk2Sos(nil, nil, 2)
+	if !AllClose(expected[0], output[0], 0) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestTf2Sos(t *testing.T) {
+	b, a := Cheby1([]float64{0.3}, 6, 1, "lowpass")
+	sos := Tf2Sos(b, a)
+	outB, outA := Sos2Tf(sos)
+	if !AllClose(b, outB, 1e-10) || !AllClose(a, outA, 1e-10) {
+		t.Errorf("Got %v, %v, want %v, %v", outB, outA, b, a)
+	}
+}
+
+func TestSosFilt(t *testing.T) {
+	b, a := Butterworth([]float64{0.3}, 4, "lowpass")
+	sos := ButterworthSOS([]float64{0.3}, 4, "lowpass")
+	x := Apply(Arange(0.0, 60, 1), math.Cos)
+	expected := Filter(b, a, x)
+	output := SosFilt(sos, x)
+	if !AllClose(expected, output, 1e-10) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	// a narrow band at low normalized frequency stays stable as sections
+	sos = ButterworthSOS([]float64{0.01, 0.02}, 8, "bandpass")
+	center := 2 / math.Pi * math.Atan(math.Sqrt(math.Tan(0.005*math.Pi)*math.Tan(0.01*math.Pi)))
+	x = Apply(Arange(0.0, 20000, 1), func(v float64) float64 { return math.Sin(math.Pi * center * v) })
+	output = SosFilt(sos, x)
+	peak, _ := Max(Abs(output[15000:]))
+	if math.Abs(peak-1) > 1e-3 {
+		t.Errorf("SosFilt passband peak = %v, want 1", peak)
+	}
+}
+
+func TestSosFiltZi(t *testing.T) {
+	sos := ButterworthSOS([]float64{0.25}, 5, "lowpass")
+	output, _ := SosFiltWithState(sos, Ones(10), SosFiltZi(sos))
+	if !AllClose(Ones(10), output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, Ones(10))
+	}
+}
+
+func TestSosFiltFilt(t *testing.T) {
+	b, a := Butterworth([]float64{0.25}, 4, "lowpass")
+	sos := ButterworthSOS([]float64{0.25}, 4, "lowpass")
+	x := Apply(Arange(0.0, 100, 1), func(v float64) float64 { return math.Sin(0.1*v) + 0.3*math.Cos(2*v) })
+	expected := FiltFiltWith(b, a, x, FiltFiltOptions{PadLen: 15})
+	output := SosFiltFiltWith(sos, x, FiltFiltOptions{PadLen: 15})
+	if !AllClose(expected, output, 1e-9) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	expected = Repeat(3.0, 50)
+	output = SosFiltFilt(sos, expected)
+	if !AllClose(expected, output, 1e-9) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	expected = FiltFiltWith(b, a, x[:20], FiltFiltOptions{PadType: "none"})
+	if output := SosFiltFiltWith(sos, x[:20], FiltFiltOptions{PadType: "none"}); !AllClose(expected, output, 1e-9) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := SosFiltFiltWith(sos, nil, FiltFiltOptions{PadType: "none"}); output == nil || len(output) != 0 {
+		t.Errorf("Got %v, want []", output)
+	}
+}
@@ -0,0 +1,310 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// SpectralOptions configures Periodogram, Welch and CSD. The zero value selects the scipy defaults.
+type SpectralOptions struct {
+	// Fs is the sampling frequency, defaulting to 1.
+	Fs float64
+	// Window names the periodic window of GetWindow applied to each segment, "hann" for Welch and CSD and
+	// "boxcar" for Periodogram by default. WindowValues gives the window explicitly instead, as for windows
+	// with parameters, its length setting Nperseg.
+	Window       string
+	WindowValues []float64
+	// Nperseg is the length of each segment, defaulting to 256 and limited to the length of the signal.
+	Nperseg int
+	// Noverlap is the number of points shared by consecutive segments, defaulting to Nperseg/2.
+	// NoOverlap selects segments without overlap, scipy's noverlap=0, which a zero Noverlap cannot since
+	// it means the default.
+	Noverlap  int
+	NoOverlap bool
+	// Nfft is the length of the FFT of each segment, zero-padded from Nperseg, which it defaults to.
+	Nfft int
+	// Detrend is removed from each segment: "constant" (default) for the mean, "linear" for the
+	// least-squares line or "none".
+	Detrend string
+	// TwoSided returns the spectrum at the negative frequencies too, in the order of FFTFreq, instead of
+	// folding their power onto the positive ones.
+	TwoSided bool
+	// Scaling is "density" (default) for the power spectral density in V**2/Hz or "spectrum" for the
+	// power spectrum in V**2.
+	Scaling string
+	// Average combines the segments with their "mean" (default) or bias corrected "median".
+	Average string
+}
+
+// Periodogram estimates the power spectral density of x from a single windowed segment like
+// scipy.signal.periodogram, returning the sample frequencies and the spectrum. Nperseg and Noverlap are
+// not used.
+func Periodogram(x []float64, opts SpectralOptions) ([]float64, []float64) {
+	if opts.Window == "" && opts.WindowValues == nil {
+		opts.Window = "boxcar"
+	}
+	opts.Nperseg, opts.NoOverlap = len(x), true
+	if opts.WindowValues != nil && len(opts.WindowValues) != len(x) {
+		panic("periodogram: the window must have the same length as x")
+	}
+	f, pxx := spectralHelper("periodogram", x, nil, opts)
+	return f, Real(pxx)
+}
+
+// Welch estimates the power spectral density of x by averaging the modified periodograms of overlapping
+// segments like scipy.signal.welch, returning the sample frequencies and the spectrum.
+func Welch(x []float64, opts SpectralOptions) ([]float64, []float64) {
+	f, pxx := spectralHelper("welch", x, nil, opts)
+	return f, Real(pxx)
+}
+
+// CSD estimates the cross power spectral density of x and y with Welch's method like scipy.signal.csd,
+// returning the sample frequencies and the complex spectrum conj(X) Y. The shorter signal is zero-padded
+// to the length of the other.
+func CSD(x, y []float64, opts SpectralOptions) ([]float64, []complex128) {
+	return spectralHelper("csd", x, y, opts)
+}
+
+// spectralHelper computes the averaged cross spectrum of x and y shared by Periodogram, Welch and CSD,
+// following scipy.signal's _spectral_helper. y is nil for the power spectrum of x.
+func spectralHelper(name string, x, y []float64, opts SpectralOptions) ([]float64, []complex128) {
+	if len(x) == 0 || (y != nil && len(y) == 0) {
+		panic(fmt.Sprintf("%s: empty input", name))
+	}
+	if y != nil && len(x) < len(y) {
+		x = append(append([]float64{}, x...), make([]float64, len(y)-len(x))...)
+	}
+	if y != nil && len(y) < len(x) {
+		y = append(append([]float64{}, y...), make([]float64, len(x)-len(y))...)
+	}
+	plan, err := newSegmentPlan(name, len(x), opts, func(n int) []float64 { return Hann(n, false) }, 2)
+	if err != nil {
+		panic(err)
+	}
+	segments := plan.spectra(name, x, y, opts, false)
+	return plan.freqs, averageSegments(name, segments, plan.terms, opts.Average)
+}
+
+// segmentPlan is the resolved segmentation of a signal for spectral estimation
+type segmentPlan struct {
+	fs                      float64
+	win                     []float64
+	nperseg, noverlap, nfft int
+	// terms is the number of frequencies kept, nfft/2+1 for one-sided spectra
+	terms int
+	freqs []float64
+	scale float64
+}
+
+// newSegmentPlan applies the defaults to opts for a signal of n points, using defaultWindow when no window
+// is given and nperseg/overlapDivisor points of overlap by default, and checks them
+func newSegmentPlan(name string, n int, opts SpectralOptions, defaultWindow func(n int) []float64, overlapDivisor int) (segmentPlan, error) {
+	plan := segmentPlan{fs: opts.Fs, win: opts.WindowValues, nperseg: opts.Nperseg}
+	if plan.fs == 0 {
+		plan.fs = 1
+	}
+	if plan.win != nil {
+		if len(plan.win) > n {
+			return segmentPlan{}, fmt.Errorf("%s: the window is longer than the input signal", name)
+		}
+		plan.nperseg = len(plan.win)
+	} else {
+		if plan.nperseg <= 0 {
+			plan.nperseg = 256
+		}
+		if plan.nperseg > n {
+			plan.nperseg = n
+		}
+		if opts.Window == "" {
+			plan.win = defaultWindow(plan.nperseg)
+		} else {
+			win, err := TryGetWindow(opts.Window, plan.nperseg, false)
+			if err != nil {
+				return segmentPlan{}, fmt.Errorf("%s: %w", name, err)
+			}
+			plan.win = win
+		}
+	}
+	switch opts.Detrend {
+	case "", "constant", "linear", "none":
+	default:
+		return segmentPlan{}, fmt.Errorf("%s: unknown detrend type %q", name, opts.Detrend)
+	}
+	plan.nfft = opts.Nfft
+	if plan.nfft == 0 {
+		plan.nfft = plan.nperseg
+	}
+	if plan.nfft < plan.nperseg {
+		return segmentPlan{}, fmt.Errorf("%s: nfft must be greater than or equal to nperseg", name)
+	}
+	plan.noverlap = opts.Noverlap
+	switch {
+	case opts.NoOverlap:
+		plan.noverlap = 0
+	case plan.noverlap == 0:
+		plan.noverlap = plan.nperseg / overlapDivisor
+	case plan.noverlap < 0:
+		return segmentPlan{}, fmt.Errorf("%s: noverlap must be non-negative", name)
+	case plan.noverlap >= plan.nperseg:
+		return segmentPlan{}, fmt.Errorf("%s: noverlap must be less than nperseg", name)
+	}
+
+	switch opts.Scaling {
+	case "", "density":
+		plan.scale = 1 / (plan.fs * Sum(Apply(plan.win, func(v float64) float64 { return v * v })))
+	case "spectrum":
+		plan.scale = 1 / math.Pow(Sum(plan.win), 2)
+	default:
+		return segmentPlan{}, fmt.Errorf("%s: unknown scaling %q", name, opts.Scaling)
+	}
+	plan.terms = plan.nfft
+	if opts.TwoSided {
+		plan.freqs = FFTFreq(plan.nfft, 1/plan.fs)
+	} else {
+		plan.freqs = RFFTFreq(plan.nfft, 1/plan.fs)
+		plan.terms = plan.nfft/2 + 1
+	}
+	return plan, nil
+}
+
+// times returns the times of the centres of the segments of a signal of n points, offset by start
+func (plan segmentPlan) times(n int, start float64) []float64 {
+	var times []float64
+	for i := 0; i+plan.nperseg <= n; i += plan.nperseg - plan.noverlap {
+		times = append(times, (float64(i)+float64(plan.nperseg)/2)/plan.fs-start)
+	}
+	return times
+}
+
+// spectra returns the spectrum of each segment: the scaled cross spectrum conj(X) Y, with the power of the
+// negative frequencies folded onto the positive ones for one-sided output, or, for stft, the transform X
+// scaled by the square root of the scale. y is nil for the power spectrum of x.
+func (plan segmentPlan) spectra(name string, x, y []float64, opts SpectralOptions, stft bool) [][]complex128 {
+	step := plan.nperseg - plan.noverlap
+	n := len(x)
+	var segments [][]complex128
+	for start := 0; start+plan.nperseg <= n; start += step {
+		xs := windowSegment(name, x[start:start+plan.nperseg], plan.win, opts.Detrend)
+		fx := FFT(toComplex(xs), plan.nfft, "")[:plan.terms]
+		if stft {
+			scaleComplex(fx, math.Sqrt(plan.scale))
+			segments = append(segments, fx)
+			continue
+		}
+		fy := fx
+		if y != nil {
+			ys := windowSegment(name, y[start:start+plan.nperseg], plan.win, opts.Detrend)
+			fy = FFT(toComplex(ys), plan.nfft, "")[:plan.terms]
+		}
+		result := make([]complex128, plan.terms)
+		for k := range result {
+			result[k] = cmplx.Conj(fx[k]) * fy[k] * complex(plan.scale, 0)
+		}
+		if !opts.TwoSided {
+			// fold the power of the negative frequencies, which the zero and Nyquist terms do not have
+			last := plan.terms
+			if plan.nfft%2 == 0 {
+				last--
+			}
+			for k := 1; k < last; k++ {
+				result[k] *= 2
+			}
+		}
+		segments = append(segments, result)
+	}
+	return segments
+}
+
+// windowSegment returns the detrended segment multiplied by the window
+func windowSegment(name string, segment, win []float64, detrend string) []float64 {
+	result := make([]float64, len(segment))
+	switch detrend {
+	case "", "constant":
+		mean := Mean(segment)
+		for i, v := range segment {
+			result[i] = v - mean
+		}
+	case "linear":
+		// remove the least-squares line through the points (i, segment[i])
+		m := float64(len(segment))
+		ti := (m - 1) / 2
+		var num, den float64
+		for i, v := range segment {
+			num += (float64(i) - ti) * v
+			den += (float64(i) - ti) * (float64(i) - ti)
+		}
+		slope := 0.0
+		if den > 0 {
+			slope = num / den
+		}
+		mean := Mean(segment)
+		for i, v := range segment {
+			result[i] = v - mean - slope*(float64(i)-ti)
+		}
+	case "none":
+		copy(result, segment)
+	default:
+		panic(fmt.Sprintf("%s: unknown detrend type %q", name, detrend))
+	}
+	for i := range result {
+		result[i] *= win[i]
+	}
+	return result
+}
+
+// averageSegments combines the spectra of the segments with their mean or with their median divided by
+// its bias relative to the mean for exponentially distributed values, taken on the real and imaginary
+// parts separately
+func averageSegments(name string, segments [][]complex128, terms int, average string) []complex128 {
+	result := make([]complex128, terms)
+	if len(segments) == 0 {
+		for k := range result {
+			result[k] = cmplx.NaN()
+		}
+		return result
+	}
+	switch average {
+	case "", "mean":
+		for _, segment := range segments {
+			for k, v := range segment {
+				result[k] += v
+			}
+		}
+		for k := range result {
+			result[k] /= complex(float64(len(segments)), 0)
+		}
+	case "median":
+		bias := medianBias(len(segments))
+		re, im := make([]float64, len(segments)), make([]float64, len(segments))
+		for k := range result {
+			for i, segment := range segments {
+				re[i], im[i] = real(segment[k]), imag(segment[k])
+			}
+			result[k] = complex(median(re)/bias, median(im)/bias)
+		}
+	default:
+		panic(fmt.Sprintf("%s: unknown average %q", name, average))
+	}
+	return result
+}
+
+// medianBias returns the ratio of the median to the mean of n exponentially distributed values
+func medianBias(n int) float64 {
+	bias := 1.0
+	for i := 2; i <= 2*((n-1)/2); i += 2 {
+		bias += 1/float64(i+1) - 1/float64(i)
+	}
+	return bias
+}
+
+// median returns the median of values, which it sorts
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
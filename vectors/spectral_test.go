@@ -0,0 +1,108 @@
+package vectors
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestPeriodogram(t *testing.T) {
+	f, pxx := Periodogram([]float64{1, 2, 3, 4}, SpectralOptions{})
+	if !AllClose([]float64{0, 0.25, 0.5}, f, 1e-15) || !AllClose([]float64{0, 4, 1}, pxx, 1e-12) {
+		t.Errorf("Got %v and %v, want [0 0.25 0.5] and [0 4 1]", f, pxx)
+	}
+
+	// without detrending the spectrum integrates to the mean square of the signal
+	for _, n := range []int{64, 75} {
+		x := Apply(Arange(0, float64(n), 1), func(v float64) float64 { return math.Sin(v*v) + 0.3 })
+		meanSquare := Mean(Apply(x, func(v float64) float64 { return v * v }))
+		for _, twoSided := range []bool{false, true} {
+			f, pxx := Periodogram(x, SpectralOptions{Fs: 50, Detrend: "none", TwoSided: twoSided})
+			if len(f) != len(pxx) || math.Abs(Sum(pxx)*50/float64(n)-meanSquare) > 1e-12 {
+				t.Errorf("Got power %v for %d points, want %v", Sum(pxx)*50/float64(n), n, meanSquare)
+			}
+		}
+	}
+}
+
+// referenceX and referenceY are the signals of the reference values of the Welch and CSD tests, which
+// follow scipy.signal.welch and csd with fs=10, nperseg=8 and the default Hann window and overlap. They
+// were not produced by scipy but by a plain Python transcription of its algorithm: segments detrended,
+// windowed, transformed with a direct DFT, scaled and averaged, with the median bias correction.
+var (
+	referenceX = []float64{1, 3, -2, 4, 0, 5, -1, 2, 6, -3, 1, 0, 2, 4, -2, 3, 1, -1, 5, 2, 0, -4, 3, 1}
+	referenceY = []float64{0, 2, 1, -1, 3, 2, -2, 1, 4, 0, -1, 2, 3, -3, 1, 2, 0, 1, -2, 4, 2, 1, -1, 0}
+)
+
+func TestWelch(t *testing.T) {
+	for _, c := range []struct {
+		detrend, average string
+		expected         []float64
+	}{
+		{"", "", []float64{0.04714886980224208, 0.7272638294202628, 1.596470418323181, 2.148054352011421, 1.1466988933062603}},
+		{"", "median", []float64{0.03556538032454916, 0.4363805240387743, 1.079058058564641, 2.055276164818012, 1.386002885611297}},
+		{"linear", "", []float64{0.05381850265636197, 0.6402613394199003, 1.5712393417793462, 2.1514727225851074, 1.1466988933062607}},
+	} {
+		f, pxx := Welch(referenceX, SpectralOptions{Fs: 10, Nperseg: 8, Detrend: c.detrend, Average: c.average})
+		if !AllClose([]float64{0, 1.25, 2.5, 3.75, 5}, f, 1e-15) || !AllClose(c.expected, pxx, 1e-12) {
+			t.Errorf("Welch with detrend %q and average %q: got %v, want %v", c.detrend, c.average, pxx, c.expected)
+		}
+	}
+
+	fs, amplitude := 1024.0, 3.0
+	x := Apply(Arange(0.0, 4096, 1), func(v float64) float64 { return amplitude * math.Sin(2*math.Pi*64*v/fs) })
+
+	// the power spectrum of a sine centred on a bin peaks at its mean square
+	f, pxx := Welch(x, SpectralOptions{Fs: fs, Scaling: "spectrum"})
+	if len(f) != 129 || f[16] != 64 || math.Abs(pxx[16]-amplitude*amplitude/2) > 1e-10 {
+		t.Errorf("Got %d frequencies, peak at %v Hz of %v", len(f), f[16], pxx[16])
+	}
+	// the density divides by the equivalent noise bandwidth, 1.5 bins for a Hann window
+	_, density := Welch(x, SpectralOptions{Fs: fs, NoOverlap: true})
+	if math.Abs(density[16]*1.5*fs/256-amplitude*amplitude/2) > 1e-10 {
+		t.Errorf("Got density %v", density[16])
+	}
+	// identical segments have the same median, once the bias is removed, and mean
+	_, median := Welch(x, SpectralOptions{Fs: fs, Nperseg: 512, NoOverlap: true, Average: "median", Nfft: 1024})
+	_, mean := Welch(x, SpectralOptions{Fs: fs, Nperseg: 512, NoOverlap: true, Nfft: 1024})
+	if bias := medianBias(8); !AllClose(mean, MultiplyBy(median, bias), 1e-10) {
+		t.Errorf("Got median %v and mean %v", median[32], mean[32])
+	}
+
+	// a linear trend is removed from each segment
+	_, pxx = Welch(Arange(0.0, 100, 1), SpectralOptions{Nperseg: 20, Detrend: "linear"})
+	if peak, _ := Max(pxx); peak > 1e-20 {
+		t.Errorf("Got %v, want zeros", pxx)
+	}
+}
+
+func TestCSD(t *testing.T) {
+	expected := []complex128{
+		complex(-0.0016666666666666774, 0),
+		complex(-0.002016053496880388, -0.3037885399319478),
+		complex(0.5946159210361676, -0.35977501175200904),
+		complex(-0.07906433363335434, 0.4925364355011629),
+		complex(-0.22902964149973554, 0),
+	}
+	if _, output := CSD(referenceX, referenceY, SpectralOptions{Fs: 10, Nperseg: 8}); !complexClose(expected, output, 1e-12) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+
+	x := Apply(Arange(0.0, 500, 1), func(v float64) float64 { return math.Sin(0.3*v) + math.Cos(0.05*v*v) })
+	_, pxx := Welch(x, SpectralOptions{Fs: 10, Nperseg: 64})
+	f, pxy := CSD(x, MultiplyBy(x, 2), SpectralOptions{Fs: 10, Nperseg: 64})
+	if len(f) != 33 || !AllClose(MultiplyBy(pxx, 2), Real(pxy), 1e-12) || !AllClose(Zeros(1, 33)[0], Imaginary(pxy), 1e-12) {
+		t.Errorf("Got %v, want %v", pxy, MultiplyBy(pxx, 2))
+	}
+
+	// a delayed copy has a linear phase of -2 pi f tau
+	shifted := append([]float64{0, 0}, x[:len(x)-2]...)
+	f, pxy = CSD(x, shifted, SpectralOptions{Nperseg: 128, Window: "hamming", Detrend: "none", TwoSided: true})
+	if len(f) != 128 {
+		t.Errorf("Got %d frequencies, want 128", len(f))
+	}
+	k := 19
+	if phase := cmplx.Phase(pxy[k]); math.Abs(phase+2*math.Pi*f[k]*2) > 0.05 {
+		t.Errorf("Got phase %v at %v, want %v", phase, f[k], -2*math.Pi*f[k]*2)
+	}
+}
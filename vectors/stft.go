@@ -0,0 +1,344 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+)
+
+// STFTOptions configures STFT and ISTFT. The zero value selects the scipy defaults.
+type STFTOptions struct {
+	// Fs is the sampling frequency, defaulting to 1.
+	Fs float64
+	// Window names the periodic window of GetWindow applied to each segment, defaulting to "hann".
+	// WindowValues gives the window explicitly instead, its length setting Nperseg.
+	Window       string
+	WindowValues []float64
+	// Nperseg is the length of each segment, defaulting to 256 and limited to the length of the signal.
+	// ISTFT defaults it to the segment length implied by the number of frequencies.
+	Nperseg int
+	// Noverlap is the number of points shared by consecutive segments, defaulting to Nperseg/2.
+	// NoOverlap selects segments without overlap, scipy's noverlap=0.
+	Noverlap  int
+	NoOverlap bool
+	// Nfft is the length of the FFT of each segment, zero-padded from Nperseg, which it defaults to.
+	Nfft int
+	// Detrend is removed from each segment by STFT: "none" (default), "constant" or "linear".
+	Detrend string
+	// TwoSided keeps the negative frequencies, in the order of FFTFreq.
+	TwoSided bool
+	// Boundary extends the signal by Nperseg/2 points at both ends so that the first and last samples are
+	// centred in a segment: "zeros" (default), "even", "odd", "constant" or "none".
+	Boundary string
+	// NoPadding drops the samples after the last full segment instead of zero-padding the signal to fit
+	// a whole number of segments.
+	NoPadding bool
+	// Scaling is "spectrum" (default), so that a sine of amplitude A gives terms of magnitude A/2, or "psd"
+	// for the square root of the power spectral density.
+	Scaling string
+}
+
+// spectralOptions returns the options of the segment plan of STFT
+func (opts STFTOptions) spectralOptions(name string) (SpectralOptions, error) {
+	detrend := opts.Detrend
+	if detrend == "" {
+		detrend = "none"
+	}
+	var scaling string
+	switch opts.Scaling {
+	case "", "spectrum":
+		scaling = "spectrum"
+	case "psd":
+		scaling = "density"
+	default:
+		return SpectralOptions{}, fmt.Errorf("%s: unknown scaling %q", name, opts.Scaling)
+	}
+	return SpectralOptions{
+		Fs:           opts.Fs,
+		Window:       opts.Window,
+		WindowValues: opts.WindowValues,
+		Nperseg:      opts.Nperseg,
+		Noverlap:     opts.Noverlap,
+		NoOverlap:    opts.NoOverlap,
+		Nfft:         opts.Nfft,
+		Detrend:      detrend,
+		TwoSided:     opts.TwoSided,
+		Scaling:      scaling,
+	}, nil
+}
+
+// STFT returns the short-time Fourier transform of x like scipy.signal.stft: the sample frequencies, the
+// segment times and the transform with one row per frequency and one column per segment.
+func STFT(x []float64, opts STFTOptions) ([]float64, []float64, [][]complex128) {
+	f, times, zxx, err := TrySTFT(x, opts)
+	if err != nil {
+		panic(err)
+	}
+	return f, times, zxx
+}
+
+// TrySTFT is STFT returning an error for an empty signal or invalid options instead of panicking.
+func TrySTFT(x []float64, opts STFTOptions) ([]float64, []float64, [][]complex128, error) {
+	if len(x) == 0 {
+		return nil, nil, nil, fmt.Errorf("stft: %w", ErrEmpty)
+	}
+	spectral, err := opts.spectralOptions("stft")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	plan, err := newSegmentPlan("stft", len(x), spectral, func(n int) []float64 { return Hann(n, false) }, 2)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	edge := plan.nperseg / 2
+	var offset float64
+	switch opts.Boundary {
+	case "", "zeros":
+		x = append(append(make([]float64, edge), x...), make([]float64, edge)...)
+		offset = float64(plan.nperseg) / 2 / plan.fs
+	case "even", "odd", "constant":
+		x = padSignal("stft", x, opts.Boundary, edge)
+		offset = float64(plan.nperseg) / 2 / plan.fs
+	case "none":
+	default:
+		return nil, nil, nil, fmt.Errorf("stft: unknown boundary %q", opts.Boundary)
+	}
+	if !opts.NoPadding {
+		step := plan.nperseg - plan.noverlap
+		extra := ((plan.nperseg-len(x))%step + step) % step % plan.nperseg
+		x = append(append([]float64{}, x...), make([]float64, extra)...)
+	}
+
+	segments := plan.spectra("stft", x, nil, spectral, true)
+	return plan.freqs, plan.times(len(x), offset), transposeSegments(segments, plan.terms), nil
+}
+
+// ISTFT returns the times and the signal whose short-time Fourier transform is zxx, inverting STFT with
+// the same options by a weighted overlap-add like scipy.signal.istft. Perfect reconstruction requires the
+// window to satisfy the nonzero overlap-add constraint, see CheckNOLA, and ISTFT panics otherwise.
+func ISTFT(zxx [][]complex128, opts STFTOptions) ([]float64, []float64) {
+	times, x, err := TryISTFT(zxx, opts)
+	if err != nil {
+		panic(err)
+	}
+	return times, x
+}
+
+// TryISTFT is ISTFT returning an error for a malformed zxx, invalid options or a window violating the
+// NOLA constraint instead of panicking.
+func TryISTFT(zxx [][]complex128, opts STFTOptions) ([]float64, []float64, error) {
+	if len(zxx) == 0 || len(zxx[0]) == 0 {
+		return nil, nil, fmt.Errorf("istft: %w: zxx must have at least one frequency and one segment", ErrEmpty)
+	}
+	nfreq, nseg := len(zxx), len(zxx[0])
+	for _, row := range zxx {
+		if len(row) != nseg {
+			return nil, nil, fmt.Errorf("istft: %w: rows must have the same length", ErrShapeMismatch)
+		}
+	}
+	fs := opts.Fs
+	if fs == 0 {
+		fs = 1
+	}
+	nDefault := nfreq
+	if !opts.TwoSided {
+		nDefault = 2 * (nfreq - 1)
+	}
+	win := opts.WindowValues
+	nperseg := opts.Nperseg
+	if win != nil {
+		nperseg = len(win)
+	} else if nperseg == 0 {
+		nperseg = nDefault
+	}
+	if nperseg < 1 {
+		return nil, nil, fmt.Errorf("istft: nperseg must be a positive integer")
+	}
+	if win == nil {
+		if opts.Window == "" {
+			win = Hann(nperseg, false)
+		} else {
+			var err error
+			if win, err = TryGetWindow(opts.Window, nperseg, false); err != nil {
+				return nil, nil, fmt.Errorf("istft: %w", err)
+			}
+		}
+	}
+	nfft := opts.Nfft
+	if nfft == 0 {
+		nfft = nDefault
+		if !opts.TwoSided && nperseg == nDefault+1 {
+			// an odd segment length cannot be told from the number of one-sided terms
+			nfft = nperseg
+		}
+	}
+	if nfft < nperseg {
+		return nil, nil, fmt.Errorf("istft: nfft must be greater than or equal to nperseg")
+	}
+	noverlap := opts.Noverlap
+	switch {
+	case opts.NoOverlap:
+		noverlap = 0
+	case noverlap == 0:
+		noverlap = nperseg / 2
+	case noverlap < 0:
+		return nil, nil, fmt.Errorf("istft: noverlap must be non-negative")
+	case noverlap >= nperseg:
+		return nil, nil, fmt.Errorf("istft: noverlap must be less than nperseg")
+	}
+	if !CheckNOLA(win, noverlap, 0) {
+		return nil, nil, fmt.Errorf("istft: the window and overlap do not satisfy the NOLA constraint")
+	}
+	var scale float64
+	switch opts.Scaling {
+	case "", "spectrum":
+		scale = Sum(win)
+	case "psd":
+		scale = math.Sqrt(fs * Sum(Apply(win, func(v float64) float64 { return v * v })))
+	default:
+		return nil, nil, fmt.Errorf("istft: unknown scaling %q", opts.Scaling)
+	}
+
+	step := nperseg - noverlap
+	x := make([]float64, nperseg+(nseg-1)*step)
+	norm := make([]float64, len(x))
+	column := make([]complex128, nfreq)
+	for j := 0; j < nseg; j++ {
+		for k := range column {
+			column[k] = zxx[k][j]
+		}
+		var segment []float64
+		if opts.TwoSided {
+			segment = Real(IFFT(column, nfft, ""))
+		} else {
+			segment = IRFFT(column, nfft, "")
+		}
+		for i := 0; i < nperseg; i++ {
+			x[j*step+i] += segment[i] * scale * win[i]
+			norm[j*step+i] += win[i] * win[i]
+		}
+	}
+	if opts.Boundary != "none" {
+		edge := nperseg / 2
+		if len(x) <= 2*edge {
+			return nil, nil, fmt.Errorf("istft: too few segments to remove the boundary extension")
+		}
+		x, norm = x[edge:len(x)-edge], norm[edge:len(norm)-edge]
+	}
+	for i := range x {
+		if norm[i] > 1e-10 {
+			x[i] /= norm[i]
+		}
+	}
+	times := make([]float64, len(x))
+	for i := range times {
+		times[i] = float64(i) / fs
+	}
+	return times, x, nil
+}
+
+// Spectrogram returns the sample frequencies, the segment times and the spectrogram of x like
+// scipy.signal.spectrogram, with one row per frequency and one column per segment. mode is "psd"
+// (default) for the power spectral density of each segment, scaled as selected by opts.Scaling, or
+// "magnitude" for the magnitude of its short-time Fourier transform. The window defaults to a Tukey window
+// with a tapered fraction of 0.25 and Noverlap to Nperseg/8, opts.Average is not used.
+func Spectrogram(x []float64, opts SpectralOptions, mode string) ([]float64, []float64, [][]float64) {
+	f, times, sxx, err := TrySpectrogram(x, opts, mode)
+	if err != nil {
+		panic(err)
+	}
+	return f, times, sxx
+}
+
+// TrySpectrogram is Spectrogram returning an error for an empty signal or invalid options instead of
+// panicking.
+func TrySpectrogram(x []float64, opts SpectralOptions, mode string) ([]float64, []float64, [][]float64, error) {
+	if len(x) == 0 {
+		return nil, nil, nil, fmt.Errorf("spectrogram: %w", ErrEmpty)
+	}
+	var stft bool
+	switch mode {
+	case "", "psd":
+	case "magnitude":
+		stft = true
+	default:
+		return nil, nil, nil, fmt.Errorf("spectrogram: unknown mode %q", mode)
+	}
+	plan, err := newSegmentPlan("spectrogram", len(x), opts, func(n int) []float64 { return Tukey(n, 0.25, false) }, 8)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	segments := transposeSegments(plan.spectra("spectrogram", x, nil, opts, stft), plan.terms)
+	sxx := make([][]float64, len(segments))
+	for k, row := range segments {
+		sxx[k] = make([]float64, len(row))
+		for j, v := range row {
+			if stft {
+				sxx[k][j] = math.Hypot(real(v), imag(v))
+			} else {
+				sxx[k][j] = real(v)
+			}
+		}
+	}
+	return plan.freqs, plan.times(len(x), 0), sxx, nil
+}
+
+// transposeSegments returns the spectra of the segments as a matrix with one row per frequency
+func transposeSegments(segments [][]complex128, terms int) [][]complex128 {
+	result := make([][]complex128, terms)
+	for k := range result {
+		result[k] = make([]complex128, len(segments))
+		for j, segment := range segments {
+			result[k][j] = segment[k]
+		}
+	}
+	return result
+}
+
+// CheckCOLA reports whether the window satisfies the constant overlap-add constraint for segments
+// overlapping by noverlap points like scipy.signal.check_COLA, so that the shifted windows sum to a
+// constant within tol, which defaults to 1e-10 when tol <= 0.
+func CheckCOLA(window []float64, noverlap int, tol float64) bool {
+	binsums := overlapSums("check_COLA", window, noverlap, func(v float64) float64 { return v })
+	if tol <= 0 {
+		tol = 1e-10
+	}
+	m := median(append([]float64{}, binsums...))
+	for _, v := range binsums {
+		if math.Abs(v-m) >= tol {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckNOLA reports whether the window satisfies the nonzero overlap-add constraint for segments
+// overlapping by noverlap points like scipy.signal.check_NOLA, so that the shifted squared windows sum to
+// more than tol everywhere, which defaults to 1e-10 when tol <= 0. ISTFT needs it to invert STFT.
+func CheckNOLA(window []float64, noverlap int, tol float64) bool {
+	binsums := overlapSums("check_NOLA", window, noverlap, func(v float64) float64 { return v * v })
+	if tol <= 0 {
+		tol = 1e-10
+	}
+	for _, v := range binsums {
+		if v <= tol {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapSums returns the sums of f applied to the window shifted by multiples of the segment step, over
+// one step
+func overlapSums(name string, window []float64, noverlap int, f func(float64) float64) []float64 {
+	nperseg := len(window)
+	if nperseg == 0 || noverlap < 0 || noverlap >= nperseg {
+		panic(fmt.Sprintf("%s: noverlap must be non-negative and less than the window length", name))
+	}
+	step := nperseg - noverlap
+	binsums := make([]float64, step)
+	for i, v := range window {
+		binsums[i%step] += f(v)
+	}
+	return binsums
+}
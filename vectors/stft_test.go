@@ -0,0 +1,148 @@
+package vectors
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestSTFT(t *testing.T) {
+	fs, amplitude := 64.0, 2.0
+	x := Apply(Arange(0.0, 256, 1), func(v float64) float64 { return amplitude * math.Cos(2*math.Pi*8*v/fs) })
+	f, times, zxx := STFT(x, STFTOptions{Fs: fs, Nperseg: 64})
+	if len(f) != 33 || len(times) != 9 || len(zxx) != 33 || len(zxx[0]) != 9 {
+		t.Fatalf("Got %d frequencies, %d times and a %dx%d transform", len(f), len(times), len(zxx), len(zxx[0]))
+	}
+	if !AllClose(Arange(0, 4.5, 0.5), times, 1e-12) || f[8] != 8 {
+		t.Errorf("Got times %v and frequency %v", times, f[8])
+	}
+	// a segment inside the signal sees a cosine of amplitude A as a term of magnitude A/2
+	if magnitude := cmplx.Abs(zxx[8][4]); math.Abs(magnitude-amplitude/2) > 1e-12 {
+		t.Errorf("Got magnitude %v, want %v", magnitude, amplitude/2)
+	}
+
+	for _, opts := range []STFTOptions{
+		{Fs: fs, Nperseg: 64},
+		{Nperseg: 50, Noverlap: 40, Window: "hamming", Scaling: "psd"},
+		{Nperseg: 32, Boundary: "even", TwoSided: true},
+		{Nperseg: 33, Nfft: 40},
+	} {
+		_, _, zxx := STFT(x, opts)
+		_, output := ISTFT(zxx, opts)
+		if len(output) < len(x) || !AllClose(x, output[:len(x)], 1e-10) {
+			t.Errorf("ISTFT does not invert STFT with options %+v", opts)
+		}
+	}
+
+}
+
+func TestTrySTFT(t *testing.T) {
+	x := Apply(Arange(0.0, 100, 1), math.Sin)
+	if _, _, zxx, err := TrySTFT(x, STFTOptions{Nperseg: 16}); err != nil || len(zxx) != 9 {
+		t.Errorf("TrySTFT returned %d frequencies and error %v", len(zxx), err)
+	}
+	if _, _, _, err := TrySTFT(nil, STFTOptions{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TrySTFT of an empty signal returned %v", err)
+	}
+	for _, opts := range []STFTOptions{
+		{Window: "triangle"},
+		{Window: "kaiser"},
+		{Nperseg: 16, Noverlap: 16},
+		{Nperseg: 16, Nfft: 8},
+		{Detrend: "quadratic"},
+		{Boundary: "periodic"},
+		{Scaling: "density"},
+	} {
+		if _, _, _, err := TrySTFT(x, opts); err == nil {
+			t.Errorf("TrySTFT(%+v) returned no error", opts)
+		}
+	}
+}
+
+func TestTryISTFT(t *testing.T) {
+	x := Apply(Arange(0.0, 256, 1), math.Sin)
+	_, _, zxx := STFT(x, STFTOptions{Nperseg: 64})
+	if _, output, err := TryISTFT(zxx, STFTOptions{Nperseg: 64}); err != nil || !AllClose(x, output[:len(x)], 1e-10) {
+		t.Errorf("TryISTFT returned error %v", err)
+	}
+	if _, _, err := TryISTFT(nil, STFTOptions{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TryISTFT of an empty transform returned %v", err)
+	}
+	if _, _, err := TryISTFT([][]complex128{{1, 2}, {3}}, STFTOptions{}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("TryISTFT of ragged rows returned %v", err)
+	}
+	// an unknown window is reported instead of panicking
+	if _, _, err := TryISTFT(zxx, STFTOptions{Window: "triangle"}); err == nil {
+		t.Errorf("TryISTFT with an unknown window returned no error")
+	}
+
+	// a Hann window without overlap is zero at the segment edges
+	_, _, zxx = STFT(x, STFTOptions{Nperseg: 64, NoOverlap: true})
+	if _, _, err := TryISTFT(zxx, STFTOptions{Nperseg: 64, NoOverlap: true}); err == nil {
+		t.Errorf("TryISTFT accepted a window violating the NOLA constraint")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ISTFT with a window violating the NOLA constraint did not panic")
+		}
+	}()
+	ISTFT(zxx, STFTOptions{Nperseg: 64, NoOverlap: true})
+}
+
+func TestSpectrogram(t *testing.T) {
+	x := Apply(Arange(0.0, 1000, 1), func(v float64) float64 { return math.Sin(0.4*v) + math.Cos(0.002*v*v) })
+
+	// the segments of the spectrogram average to the Welch estimate
+	opts := SpectralOptions{Fs: 100, Nperseg: 128, Noverlap: 64, Window: "hann"}
+	f, times, sxx := Spectrogram(x, opts, "")
+	fw, pxx := Welch(x, opts)
+	if len(times) != 14 || times[0] != 0.64 || !AllClose(fw, f, 1e-15) {
+		t.Errorf("Got %d times starting at %v", len(times), times[0])
+	}
+	for k, row := range sxx {
+		if math.Abs(Mean(row)-pxx[k]) > 1e-12 {
+			t.Errorf("Got mean %v at %v Hz, want %v", Mean(row), f[k], pxx[k])
+		}
+	}
+
+	// the magnitude is not folded onto the positive frequencies
+	_, _, magnitude := Spectrogram(x, opts, "magnitude")
+	if math.Abs(2*magnitude[10][3]*magnitude[10][3]-sxx[10][3]) > 1e-12 {
+		t.Errorf("Got magnitude %v for density %v", magnitude[10][3], sxx[10][3])
+	}
+
+	// the default Tukey window overlaps by an eighth of the segment
+	_, times, sxx = Spectrogram(x, SpectralOptions{}, "psd")
+	if len(times) != 4 || times[1]-times[0] != 224 || len(sxx) != 129 {
+		t.Errorf("Got times %v and %d frequencies", times, len(sxx))
+	}
+}
+
+func TestTrySpectrogram(t *testing.T) {
+	x := Apply(Arange(0.0, 100, 1), math.Sin)
+	if _, _, sxx, err := TrySpectrogram(x, SpectralOptions{Nperseg: 16}, "magnitude"); err != nil || len(sxx) != 9 {
+		t.Errorf("TrySpectrogram returned %d frequencies and error %v", len(sxx), err)
+	}
+	if _, _, _, err := TrySpectrogram(nil, SpectralOptions{}, ""); !errors.Is(err, ErrEmpty) {
+		t.Errorf("TrySpectrogram of an empty signal returned %v", err)
+	}
+	if _, _, _, err := TrySpectrogram(x, SpectralOptions{}, "phase"); err == nil {
+		t.Errorf("TrySpectrogram with an unknown mode returned no error")
+	}
+	if _, _, _, err := TrySpectrogram(x, SpectralOptions{Window: "triangle"}, ""); err == nil {
+		t.Errorf("TrySpectrogram with an unknown window returned no error")
+	}
+}
+
+func TestCheckCOLA(t *testing.T) {
+	if !CheckCOLA(Hann(256, false), 128, 0) || !CheckCOLA(Ones(10), 5, 0) || !CheckCOLA(Hamming(100, false), 50, 0) {
+		t.Errorf("CheckCOLA rejected a COLA window")
+	}
+	if CheckCOLA(Hann(256, false), 64, 0) || CheckCOLA(Tukey(64, 0.5, false), 0, 0) {
+		t.Errorf("CheckCOLA accepted a non-COLA window")
+	}
+	if !CheckNOLA(Hamming(64, false), 0, 0) || !CheckNOLA(Hann(64, false), 63, 0) || CheckNOLA(Hann(64, false), 0, 0) {
+		t.Errorf("CheckNOLA gave the wrong result")
+	}
+}
@@ -0,0 +1,229 @@
+package vectors
+
+import (
+	"fmt"
+	"math"
+)
+
+// The window functions return n points of the window like their scipy.signal.windows namesakes. With sym
+// set the window is symmetric, for filter design and tapering, otherwise it is periodic, the first n
+// points of the symmetric window of n+1 points, for spectral analysis.
+
+// windowLength returns the length of the symmetric window computed for a window of n points, panicking
+// when n is negative
+func windowLength(name string, n int, sym bool) int {
+	if n < 0 {
+		panic(fmt.Sprintf("%s: window length must be a non-negative integer", name))
+	}
+	if sym {
+		return n
+	}
+	return n + 1
+}
+
+// generalCosine returns the sum of the terms a[k] cos(k x) with x spaced evenly over [-pi, pi]
+func generalCosine(name string, n int, sym bool, a []float64) []float64 {
+	if n <= 1 {
+		return Ones(n)
+	}
+	m := windowLength(name, n, sym)
+	result := make([]float64, n)
+	for i := range result {
+		x := -math.Pi + 2*math.Pi*float64(i)/float64(m-1)
+		for k, ak := range a {
+			result[i] += ak * math.Cos(float64(k)*x)
+		}
+	}
+	return result
+}
+
+// Boxcar returns a rectangular window of ones.
+func Boxcar(n int, sym bool) []float64 {
+	windowLength("boxcar", n, sym)
+	return Ones(n)
+}
+
+// Hann returns a Hann window, a raised cosine reaching zero at its ends.
+func Hann(n int, sym bool) []float64 {
+	return generalCosine("hann", n, sym, []float64{0.5, 0.5})
+}
+
+// Hamming returns a Hamming window, a raised cosine with ends at 0.08.
+func Hamming(n int, sym bool) []float64 {
+	return generalCosine("hamming", n, sym, []float64{0.54, 0.46})
+}
+
+// Blackman returns a Blackman window, a three term cosine sum with low side lobes.
+func Blackman(n int, sym bool) []float64 {
+	return generalCosine("blackman", n, sym, []float64{0.42, 0.50, 0.08})
+}
+
+// Flattop returns a flat top window, a five term cosine sum whose flat main lobe measures the amplitude of
+// sinusoids accurately.
+func Flattop(n int, sym bool) []float64 {
+	return generalCosine("flattop", n, sym, []float64{0.21557895, 0.41663158, 0.277263158, 0.083578947, 0.006947368})
+}
+
+// Bartlett returns a Bartlett window, a triangle reaching zero at its ends.
+func Bartlett(n int, sym bool) []float64 {
+	if n <= 1 {
+		return Ones(n)
+	}
+	m := float64(windowLength("bartlett", n, sym) - 1)
+	result := make([]float64, n)
+	for i := range result {
+		result[i] = 2 * float64(i) / m
+		if float64(i) > m/2 {
+			result[i] = 2 - result[i]
+		}
+	}
+	return result
+}
+
+// Kaiser returns a Kaiser window with shape parameter beta. beta = 0 gives a rectangular window and
+// 5, 6 and 8.6 windows similar to Hamming, Hann and Blackman.
+func Kaiser(n int, beta float64, sym bool) []float64 {
+	if n <= 1 {
+		return Ones(n)
+	}
+	m := float64(windowLength("kaiser", n, sym) - 1)
+	result := make([]float64, n)
+	for i := range result {
+		r := 2*float64(i)/m - 1
+		result[i] = besselI0(beta*math.Sqrt(1-r*r)) / besselI0(beta)
+	}
+	return result
+}
+
+// besselI0 returns the modified Bessel function of the first kind of order zero, summing its power series
+func besselI0(x float64) float64 {
+	q := x * x / 4
+	sum, term := 1.0, 1.0
+	for k := 1; term > 1e-17*sum; k++ {
+		term *= q / float64(k*k)
+		sum += term
+	}
+	return sum
+}
+
+// Tukey returns a Tukey window, flat in the middle with cosine tapers over the fraction alpha of its
+// length. alpha <= 0 gives a rectangular window and alpha >= 1 a Hann window.
+func Tukey(n int, alpha float64, sym bool) []float64 {
+	if n <= 1 || alpha <= 0 {
+		windowLength("tukey", n, sym)
+		return Ones(n)
+	}
+	if alpha >= 1 {
+		return Hann(n, sym)
+	}
+	m := float64(windowLength("tukey", n, sym) - 1)
+	width := int(math.Floor(alpha * m / 2))
+	result := Ones(n)
+	for i := range result {
+		switch {
+		case i <= width:
+			result[i] = 0.5 * (1 + math.Cos(math.Pi*(-1+2*float64(i)/alpha/m)))
+		case float64(i) >= m-float64(width):
+			result[i] = 0.5 * (1 + math.Cos(math.Pi*(-2/alpha+1+2*float64(i)/alpha/m)))
+		}
+	}
+	return result
+}
+
+// Gaussian returns a Gaussian window with standard deviation std, in samples.
+func Gaussian(n int, std float64, sym bool) []float64 {
+	if n <= 1 {
+		return Ones(n)
+	}
+	m := float64(windowLength("gaussian", n, sym))
+	result := make([]float64, n)
+	for i := range result {
+		x := float64(i) - (m-1)/2
+		result[i] = math.Exp(-x * x / (2 * std * std))
+	}
+	return result
+}
+
+// Cosine returns a cosine window, the positive half period of a sine.
+func Cosine(n int, sym bool) []float64 {
+	if n <= 1 {
+		return Ones(n)
+	}
+	m := float64(windowLength("cosine", n, sym))
+	result := make([]float64, n)
+	for i := range result {
+		result[i] = math.Sin(math.Pi / m * (float64(i) + 0.5))
+	}
+	return result
+}
+
+// GetWindow returns the window of n points with the given name like scipy.signal.get_window, which
+// returns periodic windows unless sym is set. Kaiser needs its beta and Gaussian its standard deviation
+// in params, Tukey takes an optional alpha defaulting to 0.5. The scipy abbreviations of the names, such as
+// "hamm" and "ksr", are accepted.
+func GetWindow(name string, n int, sym bool, params ...float64) []float64 {
+	window, err := TryGetWindow(name, n, sym, params...)
+	if err != nil {
+		panic(err)
+	}
+	return window
+}
+
+// TryGetWindow is GetWindow returning an error for an unknown name or the wrong parameters instead of
+// panicking.
+func TryGetWindow(name string, n int, sym bool, params ...float64) ([]float64, error) {
+	var err error
+	param := func(required bool, fallback float64) float64 {
+		switch {
+		case len(params) > 1:
+			err = fmt.Errorf("get_window: the %s window takes at most one parameter", name)
+		case len(params) == 1:
+			return params[0]
+		case required:
+			err = fmt.Errorf("get_window: the %s window needs a parameter", name)
+		}
+		return fallback
+	}
+	noParams := func() {
+		if len(params) > 0 {
+			err = fmt.Errorf("get_window: the %s window takes no parameters", name)
+		}
+	}
+
+	var window []float64
+	switch name {
+	case "boxcar", "box", "ones", "rect", "rectangular":
+		noParams()
+		window = Boxcar(n, sym)
+	case "hann", "han":
+		noParams()
+		window = Hann(n, sym)
+	case "hamming", "hamm", "ham":
+		noParams()
+		window = Hamming(n, sym)
+	case "blackman", "black", "blk":
+		noParams()
+		window = Blackman(n, sym)
+	case "flattop", "flat", "flt":
+		noParams()
+		window = Flattop(n, sym)
+	case "bartlett", "bart", "brt":
+		noParams()
+		window = Bartlett(n, sym)
+	case "cosine", "halfcosine":
+		noParams()
+		window = Cosine(n, sym)
+	case "kaiser", "ksr":
+		window = Kaiser(n, param(true, 0), sym)
+	case "tukey", "tuk":
+		window = Tukey(n, param(false, 0.5), sym)
+	case "gaussian", "gauss", "gss":
+		window = Gaussian(n, param(true, 0), sym)
+	default:
+		return nil, fmt.Errorf("get_window: unknown window type %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return window, nil
+}
@@ -0,0 +1,129 @@
+package vectors
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCosineSumWindows(t *testing.T) {
+	if output := Hann(5, true); !AllClose([]float64{0, 0.5, 1, 0.5, 0}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.5 1 0.5 0]", output)
+	}
+	if output := Hann(4, false); !AllClose([]float64{0, 0.5, 1, 0.5}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.5 1 0.5]", output)
+	}
+	if output := Hamming(5, true); !AllClose([]float64{0.08, 0.54, 1, 0.54, 0.08}, output, 1e-15) {
+		t.Errorf("Got %v, want [0.08 0.54 1 0.54 0.08]", output)
+	}
+	if output := Blackman(5, true); !AllClose([]float64{0, 0.34, 1, 0.34, 0}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.34 1 0.34 0]", output)
+	}
+	expected := []float64{-4.21051e-4, -0.05473684, 1.000000003, -0.05473684, -4.21051e-4}
+	if output := Flattop(5, true); !AllClose(expected, output, 1e-8) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := Hann(1, true); !reflect.DeepEqual([]float64{1}, output) {
+		t.Errorf("Got %v, want [1]", output)
+	}
+	if output := Hann(0, false); len(output) != 0 {
+		t.Errorf("Got %v, want []", output)
+	}
+}
+
+func TestBartlett(t *testing.T) {
+	if output := Bartlett(5, true); !AllClose([]float64{0, 0.5, 1, 0.5, 0}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.5 1 0.5 0]", output)
+	}
+	if output := Bartlett(6, true); !AllClose([]float64{0, 0.4, 0.8, 0.8, 0.4, 0}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.4 0.8 0.8 0.4 0]", output)
+	}
+	if output := Bartlett(4, false); !AllClose([]float64{0, 0.5, 1, 0.5}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 0.5 1 0.5]", output)
+	}
+}
+
+func TestKaiser(t *testing.T) {
+	expected := []float64{7.72686684e-06, 3.46009194e-03, 4.65200189e-02, 2.29737120e-01, 5.99885316e-01, 9.45674898e-01,
+		9.45674898e-01, 5.99885316e-01, 2.29737120e-01, 4.65200189e-02, 3.46009194e-03, 7.72686684e-06}
+	if output := Kaiser(12, 14, true); !AllClose(expected, output, 1e-9) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	if output := Kaiser(6, 0, true); !reflect.DeepEqual(Ones(6), output) {
+		t.Errorf("Got %v, want ones", output)
+	}
+}
+
+func TestTukey(t *testing.T) {
+	if output := Tukey(5, 0.5, true); !AllClose([]float64{0, 1, 1, 1, 0}, output, 1e-15) {
+		t.Errorf("Got %v, want [0 1 1 1 0]", output)
+	}
+	if output := Tukey(8, 1, false); !reflect.DeepEqual(Hann(8, false), output) {
+		t.Errorf("Got %v, want %v", output, Hann(8, false))
+	}
+	if output := Tukey(8, 0, true); !reflect.DeepEqual(Ones(8), output) {
+		t.Errorf("Got %v, want ones", output)
+	}
+	// the periodic window drops the last point of the symmetric one
+	if output := Tukey(10, 0.3, false); !reflect.DeepEqual(Tukey(11, 0.3, true)[:10], output) {
+		t.Errorf("Got %v, want %v", output, Tukey(11, 0.3, true)[:10])
+	}
+}
+
+func TestGaussianCosine(t *testing.T) {
+	expected := []float64{math.Exp(-2), math.Exp(-0.5), 1, math.Exp(-0.5), math.Exp(-2)}
+	if output := Gaussian(5, 1, true); !AllClose(expected, output, 1e-15) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+	expected = []float64{0.38268343, 0.92387953, 0.92387953, 0.38268343}
+	if output := Cosine(4, true); !AllClose(expected, output, 1e-8) {
+		t.Errorf("Got %v, want %v", output, expected)
+	}
+}
+
+func TestGetWindow(t *testing.T) {
+	if output := GetWindow("hann", 8, false); !reflect.DeepEqual(Hann(8, false), output) {
+		t.Errorf("Got %v, want %v", output, Hann(8, false))
+	}
+	if output := GetWindow("ksr", 9, true, 8.6); !reflect.DeepEqual(Kaiser(9, 8.6, true), output) {
+		t.Errorf("Got %v, want %v", output, Kaiser(9, 8.6, true))
+	}
+	if output := GetWindow("tukey", 9, false); !reflect.DeepEqual(Tukey(9, 0.5, false), output) {
+		t.Errorf("Got %v, want %v", output, Tukey(9, 0.5, false))
+	}
+	if output := GetWindow("boxcar", 3, false); !reflect.DeepEqual(Ones(3), output) {
+		t.Errorf("Got %v, want ones", output)
+	}
+
+	for _, call := range []func(){
+		func() { GetWindow("gaussian", 9, false) },
+		func() { GetWindow("hann", 9, false, 1) },
+		func() { GetWindow("triangle", 9, false) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("GetWindow did not panic")
+				}
+			}()
+			call()
+		}()
+	}
+}
+
+func TestTryGetWindow(t *testing.T) {
+	if output, err := TryGetWindow("gss", 9, true, 2); err != nil || !reflect.DeepEqual(Gaussian(9, 2, true), output) {
+		t.Errorf("Got %v, %v, want %v", output, err, Gaussian(9, 2, true))
+	}
+	for _, params := range [][]float64{nil, {1, 2}} {
+		if _, err := TryGetWindow("kaiser", 9, false, params...); err == nil {
+			t.Errorf("TryGetWindow with parameters %v returned no error", params)
+		}
+	}
+	if _, err := TryGetWindow("hann", 9, false, 1); err == nil {
+		t.Errorf("TryGetWindow with a parameter for hann returned no error")
+	}
+	if _, err := TryGetWindow("triangle", 9, false); err == nil {
+		t.Errorf("TryGetWindow with an unknown name returned no error")
+	}
+}